@@ -0,0 +1,229 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"LitTime/estimator"
+)
+
+// Расширение файлов с результатами; остальные файлы в каталоге кэша не трогаем
+const entryExt = ".json"
+
+// Cache хранит результаты оценки на диске, по файлу на результат.
+// Ключ зависит от текста, параметров оценки и версии алгоритма, поэтому устаревших записей не бывает:
+// они просто перестают запрашиваться и со временем вытесняются.
+type Cache struct {
+	dir     string
+	maxSize int64 // Предельный размер в байтах, 0 — без ограничения
+}
+
+// Stats — сведения о содержимом кэша
+type Stats struct {
+	Dir     string
+	Entries int
+	Size    int64 // Суммарный размер записей в байтах
+	MaxSize int64
+	Oldest  time.Time // Время последнего обращения к самой давней записи
+	Newest  time.Time
+}
+
+// entry — файл записи кэша
+type entry struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// DefaultDir возвращает каталог кэша по спецификации XDG: $XDG_CACHE_HOME/littime или ~/.cache/littime
+func DefaultDir() (string, error) {
+	dir := os.Getenv("XDG_CACHE_HOME")
+	if !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find the cache directory: %w", err)
+		}
+		dir = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(dir, "littime"), nil
+}
+
+// Open открывает кэш в каталоге dir, создавая его при необходимости
+func Open(dir string, maxSize int64) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+	return &Cache{dir: dir, maxSize: maxSize}, nil
+}
+
+// Key возвращает ключ результата оценки текста с параметрами opts.
+// Количество горутин на результат не влияет и в ключ не входит.
+func Key(text string, opts estimator.Options) (string, error) {
+	opts.Workers = 0
+	params, err := json.Marshal(opts)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode estimator options: %w", err)
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "littime %d\n", estimator.AlgorithmVersion)
+	hash.Write(params)
+	hash.Write([]byte{0})
+	hash.Write([]byte(text))
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+entryExt)
+}
+
+// Get возвращает сохраненный результат. Поврежденная запись удаляется и считается промахом.
+func (c *Cache) Get(key string) (*estimator.Result, bool) {
+	path := c.path(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var result estimator.Result
+	if err := json.Unmarshal(data, &result); err != nil {
+		os.Remove(path)
+		return nil, false
+	}
+
+	// Время изменения файла служит временем последнего обращения для вытеснения
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return &result, true
+}
+
+// Put сохраняет результат и вытесняет давно не запрашивавшиеся записи, если кэш превысил предельный размер
+func (c *Cache) Put(key string, result *estimator.Result) error {
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	// Запись через временный файл, чтобы параллельный запуск не прочитал неполный результат
+	tmp, err := os.CreateTemp(c.dir, key+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), c.path(key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return c.evict()
+}
+
+// evict удаляет записи, начиная с самых давних, пока размер кэша не станет не больше предельного
+func (c *Cache) evict() error {
+	if c.maxSize <= 0 {
+		return nil
+	}
+	entries, err := c.entries()
+	if err != nil {
+		return err
+	}
+
+	var size int64
+	for _, e := range entries {
+		size += e.size
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return a.modTime.Compare(b.modTime) })
+	for _, e := range entries {
+		if size <= c.maxSize {
+			break
+		}
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to evict cache entry: %w", err)
+		}
+		size -= e.size
+	}
+	return nil
+}
+
+func (c *Cache) entries() ([]entry, error) {
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache directory: %w", err)
+	}
+
+	var entries []entry
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), entryExt) {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			// Файл мог удалить параллельный запуск
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		entries = append(entries, entry{path: filepath.Join(c.dir, file.Name()), size: info.Size(), modTime: info.ModTime()})
+	}
+	return entries, nil
+}
+
+// Stats возвращает количество и размер записей
+func (c *Cache) Stats() (Stats, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return Stats{}, err
+	}
+
+	stats := Stats{Dir: c.dir, Entries: len(entries), MaxSize: c.maxSize}
+	for _, e := range entries {
+		stats.Size += e.size
+		if stats.Oldest.IsZero() || e.modTime.Before(stats.Oldest) {
+			stats.Oldest = e.modTime
+		}
+		if e.modTime.After(stats.Newest) {
+			stats.Newest = e.modTime
+		}
+	}
+	return stats, nil
+}
+
+// Clear удаляет все записи и возвращает их количество
+func (c *Cache) Clear() (int, error) {
+	entries, err := c.entries()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if err := os.Remove(e.path); err != nil && !os.IsNotExist(err) {
+			return removed, fmt.Errorf("failed to clear cache: %w", err)
+		}
+		removed++
+	}
+
+	// Временные файлы прерванных записей
+	tmpFiles, _ := fs.Glob(os.DirFS(c.dir), "*.tmp")
+	for _, name := range tmpFiles {
+		os.Remove(filepath.Join(c.dir, name))
+	}
+	return removed, nil
+}
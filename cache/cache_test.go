@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"LitTime/estimator"
+)
+
+func TestKey(t *testing.T) {
+	opts := estimator.Options{ReadingSpeed: 200, Workers: 2}
+	key, err := Key("Some text.", opts)
+	if err != nil {
+		t.Fatalf("Key() returned error: %v", err)
+	}
+
+	moreWorkers := opts
+	moreWorkers.Workers = 8
+	if other, _ := Key("Some text.", moreWorkers); other != key {
+		t.Error("Key() must not depend on the number of workers")
+	}
+	faster := opts
+	faster.ReadingSpeed = 250
+	if other, _ := Key("Some text.", faster); other == key {
+		t.Error("Key() must depend on the reading speed")
+	}
+	if other, _ := Key("Other text.", opts); other == key {
+		t.Error("Key() must depend on the text")
+	}
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	c, err := Open(dir, 0)
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+
+	result := &estimator.Result{ReadingTime: 1.5, WordCount: 300, Mode: estimator.ModeNormal,
+		TokenCounts: map[estimator.TokenClass]int{estimator.TokenWord: 300}}
+	if _, ok := c.Get("missing"); ok {
+		t.Error("Get() of a missing key must miss")
+	}
+	if err := c.Put("a", result); err != nil {
+		t.Fatalf("Put() returned error: %v", err)
+	}
+	cached, ok := c.Get("a")
+	if !ok || cached.ReadingTime != 1.5 || cached.TokenCounts[estimator.TokenWord] != 300 {
+		t.Errorf("Get() = %+v, %v; want the stored result", cached, ok)
+	}
+
+	// Поврежденная запись считается промахом и удаляется
+	if err := os.WriteFile(filepath.Join(dir, "broken.json"), []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := c.Get("broken"); ok {
+		t.Error("Get() of a corrupted entry must miss")
+	}
+
+	// При превышении размера вытесняются записи, к которым дольше всего не обращались
+	stats, err := c.Stats()
+	if err != nil || stats.Entries != 1 {
+		t.Fatalf("Stats() = %+v, %v; want 1 entry", stats, err)
+	}
+	limited, _ := Open(dir, stats.Size*2)
+	old := time.Now().Add(-time.Hour)
+	os.Chtimes(filepath.Join(dir, "a.json"), old, old)
+	limited.Put("b", result)
+	limited.Put("c", result)
+	if _, ok := limited.Get("a"); ok {
+		t.Error("Put() must evict the least recently used entry")
+	}
+	if _, ok := limited.Get("c"); !ok {
+		t.Error("Put() evicted the newest entry")
+	}
+
+	removed, err := c.Clear()
+	if err != nil || removed != 2 {
+		t.Errorf("Clear() = %d, %v; want 2 removed", removed, err)
+	}
+	if stats, _ := c.Stats(); stats.Entries != 0 {
+		t.Errorf("Stats() after Clear() = %d entries; want 0", stats.Entries)
+	}
+}
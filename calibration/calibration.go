@@ -0,0 +1,189 @@
+package calibration
+
+import (
+	_ "embed"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"LitTime/estimator"
+)
+
+//go:embed passages.yaml
+var passagesData []byte
+
+// Уровни сложности текстов в порядке возрастания
+const (
+	BandEasy   = "easy"
+	BandMedium = "medium"
+	BandHard   = "hard"
+)
+
+// Bands возвращает уровни сложности в порядке возрастания
+func Bands() []string {
+	return []string{BandEasy, BandMedium, BandHard}
+}
+
+// Question — вопрос на понимание прочитанного с вариантами ответа
+type Question struct {
+	Prompt  string   `yaml:"prompt"`
+	Choices []string `yaml:"choices"`
+	Answer  int      `yaml:"answer"` // Номер правильного варианта, начиная с 0
+}
+
+// Passage — текст для калибровки скорости чтения
+type Passage struct {
+	Language  string     `yaml:"language"`
+	Band      string     `yaml:"band"`
+	Title     string     `yaml:"title"`
+	Text      string     `yaml:"text"`
+	Questions []Question `yaml:"questions"`
+}
+
+// Words возвращает количество слов в тексте
+func (p Passage) Words() int {
+	count, _ := estimator.CountWords(p.Text)
+	return count
+}
+
+// Reading — результат чтения одного текста
+type Reading struct {
+	Passage  Passage
+	Duration time.Duration
+	Correct  int // Количество правильных ответов
+}
+
+// Comprehension возвращает долю правильных ответов
+func (r Reading) Comprehension() float64 {
+	if len(r.Passage.Questions) == 0 {
+		return 1
+	}
+	return float64(r.Correct) / float64(len(r.Passage.Questions))
+}
+
+// Summary содержит скорость чтения с учетом понимания текста
+type Summary struct {
+	Speed         int                       // Общая скорость в словах в минуту
+	Languages     map[string]int            // Скорость по языкам, только положительная
+	Bands         map[string]map[string]int // Скорость по языкам и уровням сложности, только положительная
+	Comprehension float64                   // Доля правильных ответов
+}
+
+// LoadPassages возвращает тексты на выбранных языках и уровнях сложности.
+// Пустой список означает все языки или все уровни.
+func LoadPassages(languages, bands []string) ([]Passage, error) {
+	var all []Passage
+	if err := yaml.Unmarshal(passagesData, &all); err != nil {
+		return nil, fmt.Errorf("failed to parse calibration passages: %w", err)
+	}
+
+	for _, band := range bands {
+		if !slices.Contains(Bands(), band) {
+			return nil, fmt.Errorf("unknown difficulty band %q (supported: %s)", band, strings.Join(Bands(), ", "))
+		}
+	}
+	for _, language := range languages {
+		if !slices.ContainsFunc(all, func(p Passage) bool { return p.Language == language }) {
+			return nil, fmt.Errorf("no calibration passages for language %q (supported: %s)", language, strings.Join(Languages(all), ", "))
+		}
+	}
+
+	var passages []Passage
+	for _, p := range all {
+		if (len(languages) == 0 || slices.Contains(languages, p.Language)) && (len(bands) == 0 || slices.Contains(bands, p.Band)) {
+			passages = append(passages, p)
+		}
+	}
+	if len(passages) == 0 {
+		return nil, fmt.Errorf("no calibration passages match the selected languages and bands")
+	}
+	return passages, nil
+}
+
+// Languages возвращает языки текстов в порядке появления
+func Languages(passages []Passage) []string {
+	var languages []string
+	for _, p := range passages {
+		if !slices.Contains(languages, p.Language) {
+			languages = append(languages, p.Language)
+		}
+	}
+	return languages
+}
+
+// Summarize рассчитывает скорость чтения по результатам.
+// Скорость умножается на долю правильных ответов: быстрое чтение без понимания не засчитывается.
+func Summarize(readings []Reading) Summary {
+	summary := Summary{
+		Languages: make(map[string]int),
+		Bands:     make(map[string]map[string]int),
+	}
+
+	var total, correct, questions float64
+	totalMinutes := 0.0
+	languageWords := make(map[string]float64)
+	languageMinutes := make(map[string]float64)
+	for _, r := range readings {
+		minutes := r.Duration.Minutes()
+		if minutes <= 0 {
+			continue
+		}
+		words := float64(r.Passage.Words()) * r.Comprehension()
+
+		// Нулевая скорость (ни одного правильного ответа) не годится для конфигурации
+		if speed := wpm(words, minutes); speed > 0 {
+			if summary.Bands[r.Passage.Language] == nil {
+				summary.Bands[r.Passage.Language] = make(map[string]int)
+			}
+			summary.Bands[r.Passage.Language][r.Passage.Band] = speed
+		}
+		languageWords[r.Passage.Language] += words
+		languageMinutes[r.Passage.Language] += minutes
+
+		total += words
+		totalMinutes += minutes
+		correct += float64(r.Correct)
+		questions += float64(len(r.Passage.Questions))
+	}
+
+	for language, words := range languageWords {
+		if speed := wpm(words, languageMinutes[language]); speed > 0 {
+			summary.Languages[language] = speed
+		}
+	}
+	if totalMinutes > 0 {
+		summary.Speed = wpm(total, totalMinutes)
+	}
+	summary.Comprehension = 1
+	if questions > 0 {
+		summary.Comprehension = correct / questions
+	}
+	return summary
+}
+
+// DifficultySensitivity возвращает долю, на которую сложные тексты читаются медленнее простых,
+// в среднем по языкам, для которых прочитаны оба уровня. ok равно false, если таких языков нет.
+func (s Summary) DifficultySensitivity() (sensitivity float64, ok bool) {
+	var sum float64
+	var count int
+	for _, bands := range s.Bands {
+		easy, hard := bands[BandEasy], bands[BandHard]
+		if easy <= 0 || hard <= 0 {
+			continue
+		}
+		sum += min(max(1-float64(hard)/float64(easy), 0), 0.9)
+		count++
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return math.Round(sum/float64(count)*100) / 100, true
+}
+
+func wpm(words, minutes float64) int {
+	return int(math.Round(words / minutes))
+}
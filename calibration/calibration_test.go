@@ -0,0 +1,101 @@
+package calibration
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLoadPassages(t *testing.T) {
+	passages, err := LoadPassages(nil, nil)
+	if err != nil {
+		t.Fatalf("LoadPassages() returned error: %v", err)
+	}
+
+	for _, language := range []string{"en", "ru"} {
+		for _, band := range Bands() {
+			selected, err := LoadPassages([]string{language}, []string{band})
+			if err != nil || len(selected) != 1 {
+				t.Errorf("LoadPassages(%s, %s) = %d passages, %v; want 1", language, band, len(selected), err)
+			}
+		}
+	}
+
+	for _, p := range passages {
+		if p.Words() < 100 {
+			t.Errorf("Passage %q has only %d words", p.Title, p.Words())
+		}
+		if len(p.Questions) == 0 {
+			t.Errorf("Passage %q has no questions", p.Title)
+		}
+		for _, q := range p.Questions {
+			if q.Answer < 0 || q.Answer >= len(q.Choices) {
+				t.Errorf("Question %q has answer %d outside of %d choices", q.Prompt, q.Answer, len(q.Choices))
+			}
+		}
+	}
+
+	if _, err := LoadPassages([]string{"de"}, nil); err == nil {
+		t.Error("LoadPassages() expected an error for an unknown language")
+	}
+	if _, err := LoadPassages(nil, []string{"extreme"}); err == nil {
+		t.Error("LoadPassages() expected an error for an unknown band")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	passage := func(language, band string, words int) Passage {
+		text := ""
+		for i := 0; i < words; i++ {
+			text += "word "
+		}
+		return Passage{Language: language, Band: band, Text: text, Questions: make([]Question, 4)}
+	}
+
+	summary := Summarize([]Reading{
+		{Passage: passage("en", BandEasy, 200), Duration: time.Minute, Correct: 4},
+		{Passage: passage("en", BandHard, 200), Duration: 2 * time.Minute, Correct: 2},
+		{Passage: passage("ru", BandEasy, 150), Duration: time.Minute, Correct: 4},
+		{Passage: passage("ru", BandMedium, 150), Duration: 0, Correct: 4},
+	})
+
+	if summary.Bands["en"][BandEasy] != 200 || summary.Bands["en"][BandHard] != 50 {
+		t.Errorf("Bands[en] = %v; want easy 200, hard 50", summary.Bands["en"])
+	}
+	if _, ok := summary.Bands["ru"][BandMedium]; ok {
+		t.Error("Readings without duration must be ignored")
+	}
+	if summary.Languages["en"] != 100 || summary.Languages["ru"] != 150 {
+		t.Errorf("Languages = %v; want en 100, ru 150", summary.Languages)
+	}
+	if summary.Speed != 113 {
+		t.Errorf("Speed = %d; want 113", summary.Speed)
+	}
+	if sensitivity, ok := summary.DifficultySensitivity(); !ok || sensitivity != 0.75 {
+		t.Errorf("DifficultySensitivity() = %.2f, %v; want 0.75, true", sensitivity, ok)
+	}
+	if summary.Comprehension != 10.0/12 {
+		t.Errorf("Comprehension = %.3f; want %.3f", summary.Comprehension, 10.0/12)
+	}
+}
+
+func TestSummarizeAllWrong(t *testing.T) {
+	passage := Passage{Language: "en", Band: BandHard, Text: "one two three four", Questions: make([]Question, 2)}
+	summary := Summarize([]Reading{
+		{Passage: passage, Duration: time.Minute, Correct: 0},
+		{Passage: Passage{Language: "ru", Band: BandEasy, Text: "раз два три", Questions: make([]Question, 2)}, Duration: time.Minute, Correct: 2},
+	})
+
+	// Скорость 0 не должна попасть в сводку, иначе сохраненная конфигурация не пройдет проверку
+	if _, ok := summary.Languages["en"]; ok {
+		t.Errorf("Languages = %v; want no en speed", summary.Languages)
+	}
+	if _, ok := summary.Bands["en"]; ok {
+		t.Errorf("Bands = %v; want no en speeds", summary.Bands)
+	}
+	if summary.Languages["ru"] != 3 || summary.Bands["ru"][BandEasy] != 3 {
+		t.Errorf("ru speeds = %v, %v; want 3", summary.Languages, summary.Bands)
+	}
+	if _, ok := summary.DifficultySensitivity(); ok {
+		t.Error("DifficultySensitivity() must not be measured without easy and hard speeds")
+	}
+}
@@ -0,0 +1,120 @@
+package check
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"LitTime/estimator"
+)
+
+// Rule — идентификатор правила, он выводится в отчетах
+type Rule string
+
+const (
+	RuleReadingTime    Rule = "max-reading-time"
+	RuleFlesch         Rule = "min-flesch"
+	RuleSentenceLength Rule = "max-sentence-words"
+	RuleParagraphWords Rule = "max-paragraph-words"
+	RuleEmptyText      Rule = "empty-text"
+)
+
+// Rules — пороги проверки; нулевое значение отключает правило
+type Rules struct {
+	MaxReadingTime    float64 // В минутах на файл
+	MinFlesch         float64 // Индекс Флеша-Кинкейда файла
+	MaxSentenceWords  int
+	MaxParagraphWords int
+}
+
+// RuleInfo — описание правила для отчетов SARIF
+type RuleInfo struct {
+	ID          Rule
+	Description string
+}
+
+// AllRules возвращает описания всех правил
+func AllRules() []RuleInfo {
+	return []RuleInfo{
+		{RuleReadingTime, "Reading time of a file must not exceed the limit"},
+		{RuleFlesch, "Flesch-Kincaid index of a file must not be lower than the limit"},
+		{RuleSentenceLength, "A sentence must not have more words than the limit"},
+		{RuleParagraphWords, "A paragraph must not have more words than the limit"},
+		{RuleEmptyText, "A file must contain text to estimate"},
+	}
+}
+
+// Empty сообщает, что ни одно правило не включено
+func (r Rules) Empty() bool {
+	return r == Rules{}
+}
+
+// Validate проверяет, что пороги не отрицательные
+func (r Rules) Validate() error {
+	if r.MaxReadingTime < 0 || r.MaxSentenceWords < 0 || r.MaxParagraphWords < 0 {
+		return fmt.Errorf("check limits must not be negative")
+	}
+	return nil
+}
+
+// Violation — нарушение правила в файле. Строки и столбцы считаются с 1, столбец — в символах.
+type Violation struct {
+	File    string
+	Line    int
+	Column  int
+	Rule    Rule
+	Message string
+}
+
+// FileResult — результат проверки одного файла
+type FileResult struct {
+	File       string
+	Violations []Violation
+}
+
+// Check проверяет результат оценки текста файла. Для правил предложений и абзацев
+// результат должен содержать их показатели (Options.Detailed).
+// Правила файла целиком указывают на первую строку.
+func Check(file, text string, result *estimator.Result, rules Rules) FileResult {
+	checked := FileResult{File: file}
+	add := func(offset int, rule Rule, format string, args ...any) {
+		line, column := estimator.Position(text, offset)
+		checked.Violations = append(checked.Violations, Violation{
+			File: file, Line: line, Column: column, Rule: rule, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if rules.MaxReadingTime > 0 && result.ReadingTime > rules.MaxReadingTime {
+		add(0, RuleReadingTime, "reading time %.2f min exceeds %g min", result.ReadingTime, rules.MaxReadingTime)
+	}
+	// Индекс, неприменимый к тексту (например, китайскому), не сравнивается с порогом
+	if rules.MinFlesch != 0 && !result.FleschKincaidNotApplicable && result.FleschKincaidIndex < rules.MinFlesch {
+		add(0, RuleFlesch, "Flesch-Kincaid index %.2f is below %g", result.FleschKincaidIndex, rules.MinFlesch)
+	}
+	if rules.MaxParagraphWords > 0 {
+		for _, p := range result.Paragraphs {
+			if p.WordCount > rules.MaxParagraphWords {
+				add(p.Start, RuleParagraphWords, "paragraph %d has %d words, more than %d", p.Paragraph, p.WordCount, rules.MaxParagraphWords)
+			}
+		}
+	}
+	if rules.MaxSentenceWords > 0 {
+		for _, s := range result.Sentences {
+			if s.WordCount > rules.MaxSentenceWords {
+				add(s.Start, RuleSentenceLength, "sentence has %d words, more than %d", s.WordCount, rules.MaxSentenceWords)
+			}
+		}
+	}
+	slices.SortStableFunc(checked.Violations, func(a, b Violation) int {
+		return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+	})
+	return checked
+}
+
+// CheckEmpty возвращает результат для файла без слов: оценить его нельзя, поэтому вместо
+// правил из Rules он нарушает правило RuleEmptyText
+func CheckEmpty(file string) FileResult {
+	return FileResult{File: file, Violations: []Violation{{
+		File: file, Line: 1, Column: 1, Rule: RuleEmptyText, Message: "file has no text to estimate",
+	}}}
+}
@@ -0,0 +1,82 @@
+package check
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"LitTime/estimator"
+)
+
+func TestCheck(t *testing.T) {
+	text := "Short one.\n\nThis sentence is rather long. Ok.\n"
+	opts := estimator.Options{ReadingSpeed: 200, Workers: 1, Detailed: true}
+	result, err := estimator.Estimate(text, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checked := Check("doc.md", text, &result, Rules{MaxSentenceWords: 4, MaxParagraphWords: 5, MaxReadingTime: 100})
+	var got []string
+	for _, v := range checked.Violations {
+		got = append(got, v.String())
+	}
+	want := []string{
+		"doc.md:3:1: paragraph 2 has 6 words, more than 5 [max-paragraph-words]",
+		"doc.md:3:1: sentence has 5 words, more than 4 [max-sentence-words]",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("violations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if clean := Check("doc.md", text, &result, Rules{MaxReadingTime: 100}); len(clean.Violations) != 0 {
+		t.Errorf("unexpected violations: %v", clean.Violations)
+	}
+}
+
+func TestCheckNotApplicable(t *testing.T) {
+	text := "我们今天学习中文。你好。\n"
+	result, err := estimator.Estimate(text, estimator.Options{ReadingSpeed: 200, Workers: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// У китайского текста нет индекса Флеша-Кинкейда, правило min-flesch к нему не применяется
+	if checked := Check("zh.md", text, &result, Rules{MinFlesch: 40}); len(checked.Violations) != 0 {
+		t.Errorf("unexpected violations for CJK text: %v", checked.Violations)
+	}
+
+	empty := CheckEmpty("empty.md")
+	if len(empty.Violations) != 1 || empty.Violations[0].String() != "empty.md:1:1: file has no text to estimate [empty-text]" {
+		t.Errorf("CheckEmpty() = %v", empty.Violations)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	results := []FileResult{
+		{File: "a.md", Violations: []Violation{{File: "a.md", Line: 2, Column: 1, Rule: RuleSentenceLength, Message: "too long"}}},
+		{File: "b.md"},
+	}
+
+	var junit bytes.Buffer
+	if err := Write(&junit, results, FormatJUnit); err != nil {
+		t.Fatal(err)
+	}
+	for _, part := range []string{`<testsuite name="littime check" tests="2" failures="1">`, `<testcase name="b.md" classname="littime"></testcase>`, `type="max-sentence-words"`} {
+		if !strings.Contains(junit.String(), part) {
+			t.Errorf("JUnit report misses %q:\n%s", part, junit.String())
+		}
+	}
+
+	var sarif bytes.Buffer
+	if err := Write(&sarif, results, FormatSARIF); err != nil {
+		t.Fatal(err)
+	}
+	var log sarifLog
+	if err := json.Unmarshal(sarif.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != 1 || log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine != 2 {
+		t.Errorf("unexpected SARIF report:\n%s", sarif.String())
+	}
+}
@@ -0,0 +1,215 @@
+package check
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Format — формат отчета о проверке
+type Format string
+
+const (
+	FormatText  Format = "text"  // Строки file:line:column: message, как у компиляторов
+	FormatJUnit Format = "junit" // JUnit XML, по тесту на файл
+	FormatSARIF Format = "sarif" // SARIF 2.1.0 для систем анализа кода
+)
+
+// Formats возвращает список поддерживаемых форматов
+func Formats() []string {
+	return []string{string(FormatText), string(FormatJUnit), string(FormatSARIF)}
+}
+
+// ParseFormat разбирает название формата
+func ParseFormat(name string) (Format, error) {
+	format := Format(strings.ToLower(strings.TrimSpace(name)))
+	switch format {
+	case FormatText, FormatJUnit, FormatSARIF:
+		return format, nil
+	case "xml":
+		return FormatJUnit, nil
+	}
+	return "", fmt.Errorf("unknown check format %q (supported: %s)", name, strings.Join(Formats(), ", "))
+}
+
+// Count возвращает количество нарушений и файлов с нарушениями
+func Count(results []FileResult) (violations, files int) {
+	for _, r := range results {
+		if len(r.Violations) > 0 {
+			violations += len(r.Violations)
+			files++
+		}
+	}
+	return violations, files
+}
+
+// Write записывает отчет о проверке файлов по правилам AllRules в w
+func Write(w io.Writer, results []FileResult, format Format) error {
+	return WriteRules(w, results, format, AllRules())
+}
+
+// WriteRules записывает отчет о проверке по другим правилам, например замечания о стиле;
+// описания rules попадают в отчет SARIF
+func WriteRules(w io.Writer, results []FileResult, format Format, rules []RuleInfo) error {
+	switch format {
+	case FormatText:
+		return writeText(w, results)
+	case FormatJUnit:
+		return writeJUnit(w, results)
+	case FormatSARIF:
+		return writeSARIF(w, results, rules)
+	default:
+		return fmt.Errorf("unknown check format %q", format)
+	}
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s [%s]", v.File, v.Line, v.Column, v.Message, v.Rule)
+}
+
+func writeText(w io.Writer, results []FileResult) error {
+	for _, r := range results {
+		for _, v := range r.Violations {
+			if _, err := fmt.Fprintln(w, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+type junitSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name     string      `xml:"name,attr"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Cases    []junitCase `xml:"testcase"`
+}
+
+type junitCase struct {
+	Name      string         `xml:"name,attr"`
+	Classname string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit выводит по тесту на файл; каждое нарушение — отдельный failure
+func writeJUnit(w io.Writer, results []FileResult) error {
+	suite := junitSuite{Name: "littime check", Tests: len(results)}
+	for _, r := range results {
+		testCase := junitCase{Name: r.File, Classname: "littime"}
+		for _, v := range r.Violations {
+			testCase.Failures = append(testCase.Failures, junitFailure{Message: v.Message, Type: string(v.Rule), Text: v.String()})
+		}
+		if len(testCase.Failures) > 0 {
+			suite.Failures++
+		}
+		suite.Cases = append(suite.Cases, testCase)
+	}
+	suites := junitSuites{Name: suite.Name, Tests: suite.Tests, Failures: suite.Failures, Suites: []junitSuite{suite}}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine   int `json:"startLine"`
+			StartColumn int `json:"startColumn"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+func writeSARIF(w io.Writer, results []FileResult, rules []RuleInfo) error {
+	driver := sarifDriver{Name: "littime"}
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, sarifRule{ID: string(rule.ID), ShortDescription: sarifMessage{rule.Description}})
+	}
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, r := range results {
+		for _, v := range r.Violations {
+			var location sarifLocation
+			// В SARIF пути относительные и с прямыми косыми чертами
+			location.PhysicalLocation.ArtifactLocation.URI = filepath.ToSlash(v.File)
+			location.PhysicalLocation.Region.StartLine = v.Line
+			location.PhysicalLocation.Region.StartColumn = v.Column
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    string(v.Rule),
+				Level:     "error",
+				Message:   sarifMessage{v.Message},
+				Locations: []sarifLocation{location},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
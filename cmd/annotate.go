@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+	"LitTime/estimator"
+	"LitTime/frontmatter"
+)
+
+func NewAnnotateCmd(cfg *config.Config) *cobra.Command {
+	var flags estimateFlags
+	var formatName string
+	var dryRun bool
+	var noCache bool
+
+	cmd := &cobra.Command{
+		Use:   "annotate <file>...",
+		Short: "Write reading time and readability into the front matter of Markdown files",
+		Long: `Estimate the body of every Markdown file and write reading_time, word_count and
+flesch_kincaid_index into its YAML (---) or TOML (+++) front matter, as used by Hugo
+and Jekyll. Existing keys, comments and formatting are kept; files without front matter
+get a new block in the --format format. With --dry-run the changes are printed as a diff
+and no file is modified.`,
+		Example: `  littime annotate content/posts/*.md
+  littime annotate _posts/2024-01-01-hello.md --dry-run`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := frontmatter.ParseFormat(formatName)
+			if err != nil {
+				return usageError("%v", err)
+			}
+			opts, _, err := buildOptions(cmd, cfg, flags)
+			if err != nil {
+				return err
+			}
+			cmd.SilenceUsage = true
+
+			for _, path := range args {
+				if err := annotateFile(cfg, path, opts, format, dryRun, !noCache); err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+			}
+			return nil
+		},
+	}
+
+	flags.addFlags(cmd, cfg, "the posts contain")
+	cmd.Flags().StringVar(&formatName, "format", string(frontmatter.FormatYAML), "Front matter format for files that have none: yaml or toml")
+	cmd.Flags().BoolVarP(&dryRun, "dry-run", "n", false, "Print the changes as a diff without modifying files")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not read or write the result cache")
+
+	return cmd
+}
+
+// annotateFile оценивает текст файла без front matter и записывает показатели в front matter
+func annotateFile(cfg *config.Config, path string, opts estimator.Options, format frontmatter.Format, dryRun, useCache bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	content := string(data)
+	doc, err := frontmatter.Parse(content)
+	if err != nil {
+		return err
+	}
+
+	// Переводы строк приводятся к \n, как при оценке файла командой run
+	body, err := estimator.ReadText(strings.NewReader(doc.Body))
+	if err != nil {
+		return err
+	}
+	result, err := estimateCached(cfg, body, opts, useCache, false)
+	if err != nil {
+		return err
+	}
+
+	fields := []frontmatter.Field{
+		{Key: "reading_time", Value: result.ReadingTime},
+		{Key: "word_count", Value: result.WordCount},
+	}
+	// Для текста, к которому индекс неприменим, ключ не записывается, чтобы 0 не выглядел оценкой
+	if !result.FleschKincaidNotApplicable {
+		fields = append(fields, frontmatter.Field{Key: "flesch_kincaid_index", Value: math.Round(result.FleschKincaidIndex*100) / 100})
+	}
+	doc.Set(fields, format)
+	annotated := doc.String()
+
+	if dryRun {
+		fmt.Print(frontmatter.UnifiedDiff(path, content, annotated))
+		return nil
+	}
+	if annotated == content {
+		fmt.Fprintf(os.Stderr, "Up to date: %s\n", path)
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(annotated), info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Annotated: %s (%.2f min)\n", path, result.ReadingTime)
+	return nil
+}
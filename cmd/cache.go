@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/cache"
+	"LitTime/config"
+	"LitTime/estimator"
+)
+
+func NewCacheCmd(cfg *config.Config) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Inspect or clear the result cache",
+	}
+	cmd.AddCommand(newCacheStatsCmd(cfg), newCacheClearCmd(cfg))
+	return cmd
+}
+
+func newCacheStatsCmd(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats",
+		Short: "Show the location, number of entries and size of the cache",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := openCache(cfg)
+			if err != nil {
+				return err
+			}
+			stats, err := c.Stats()
+			if err != nil {
+				return err
+			}
+
+			limit := "none"
+			if stats.MaxSize > 0 {
+				limit = formatSize(stats.MaxSize)
+			}
+			fmt.Printf("Directory: %s\n", stats.Dir)
+			fmt.Printf("Enabled:   %t\n", cfg.Cache)
+			fmt.Printf("Entries:   %d\n", stats.Entries)
+			fmt.Printf("Size:      %s (limit %s)\n", formatSize(stats.Size), limit)
+			if stats.Entries > 0 {
+				fmt.Printf("Used:      %s — %s\n", stats.Oldest.Format("2006-01-02 15:04"), stats.Newest.Format("2006-01-02 15:04"))
+			}
+			return nil
+		},
+	}
+}
+
+func newCacheClearCmd(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all cached results",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			c, err := openCache(cfg)
+			if err != nil {
+				return err
+			}
+			removed, err := c.Clear()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Removed %d cached results\n", removed)
+			return nil
+		},
+	}
+}
+
+func openCache(cfg *config.Config) (*cache.Cache, error) {
+	dir := cfg.CacheDir
+	if dir == "" {
+		var err error
+		if dir, err = cache.DefaultDir(); err != nil {
+			return nil, err
+		}
+	}
+	return cache.Open(dir, int64(cfg.CacheMaxSize)<<20)
+}
+
+// estimateCached возвращает результат из кэша или оценивает текст и сохраняет результат в кэш.
+// Ошибки кэша не прерывают оценку, а выводятся предупреждением.
+func estimateCached(cfg *config.Config, text string, opts estimator.Options, useCache, quiet bool) (*estimator.Result, error) {
+	if !cfg.Cache || !useCache {
+		return estimateText(text, opts)
+	}
+	warn := func(err error) {
+		if !quiet {
+			fmt.Fprintf(os.Stderr, "Warning: result cache: %v\n", err)
+		}
+	}
+
+	c, err := openCache(cfg)
+	if err != nil {
+		warn(err)
+		return estimateText(text, opts)
+	}
+	key, err := cache.Key(text, opts)
+	if err != nil {
+		warn(err)
+		return estimateText(text, opts)
+	}
+	if result, ok := c.Get(key); ok {
+		return result, nil
+	}
+
+	result, err := estimateText(text, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.Put(key, result); err != nil {
+		warn(err)
+	}
+	return result, nil
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	case bytes >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	default:
+		return fmt.Sprintf("%d B", bytes)
+	}
+}
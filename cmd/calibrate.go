@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/calibration"
+	"LitTime/config"
+	"LitTime/ui"
+)
+
+// defaultCalibrationProfile — профиль, в который calibrate сохраняет результат, если профиль не выбран
+const defaultCalibrationProfile = "default"
+
+// NewCalibrateCmd создает команду для измерения личной скорости чтения
+func NewCalibrateCmd(cfg *config.Config) *cobra.Command {
+	var languages []string
+	var bands []string
+	var noSave bool
+
+	cmd := &cobra.Command{
+		Use:   "calibrate",
+		Short: "Measure your reading speed on sample passages and save it to the config",
+		Long: `Shows sample passages one by one, times how long you take to read each of them
+and asks a few comprehension questions. The resulting speed is multiplied by the share
+of correct answers and saved to the reader profile chosen with --profile (or default_profile)
+as reading_speed, per-language language_speeds and the measured difficulty_sensitivity.
+Without a profile the result is saved to a new "default" profile, which becomes default_profile.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !ui.IsTerminal() {
+				return usageError("calibrate requires an interactive terminal")
+			}
+			passages, err := calibration.LoadPassages(languages, bands)
+			if err != nil {
+				return usageError("%v", err)
+			}
+			cmd.SilenceUsage = true
+
+			readings, err := ui.RunCalibration(passages)
+			if err != nil {
+				return err
+			}
+
+			summary := calibration.Summarize(readings)
+			printCalibration(summary)
+			if summary.Speed <= 0 || noSave {
+				return nil
+			}
+
+			// Результат сохраняется в профиль: выбранный флагом, профиль по умолчанию
+			// или новый профиль default, который становится профилем по умолчанию
+			profile, _ := cmd.Flags().GetString("profile")
+			if profile == "" {
+				profile = cfg.DefaultProfile
+			}
+			values := make(map[string]any)
+			if profile == "" {
+				profile = defaultCalibrationProfile
+				values["default_profile"] = profile
+			}
+			profile = strings.ToLower(profile)
+			prefix := "profiles." + profile + "."
+			values[prefix+"reading_speed"] = summary.Speed
+			if len(summary.Languages) > 0 {
+				values[prefix+"language_speeds"] = summary.Languages
+			}
+			if sensitivity, ok := summary.DifficultySensitivity(); ok {
+				values[prefix+"difficulty_sensitivity"] = sensitivity
+			}
+
+			path, err := config.Save(values)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Saved reading speed to profile %q in: %s\n", profile, path)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&languages, "lang", "l", nil, "Passage languages to read, e.g. en,ru (default all)")
+	cmd.Flags().StringSliceVar(&bands, "bands", nil, "Difficulty bands to read: easy, medium, hard (default all)")
+	cmd.Flags().BoolVar(&noSave, "no-save", false, "Print the measured speed without saving it to the config")
+
+	return cmd
+}
+
+func printCalibration(summary calibration.Summary) {
+	fmt.Printf("Reading speed: %d wpm (comprehension %.0f%%)\n", summary.Speed, summary.Comprehension*100)
+	for _, language := range slices.Sorted(maps.Keys(summary.Languages)) {
+		fmt.Printf("  %s: %d wpm", language, summary.Languages[language])
+		for _, band := range calibration.Bands() {
+			if bandSpeed, ok := summary.Bands[language][band]; ok {
+				fmt.Printf(", %s %d", band, bandSpeed)
+			}
+		}
+		fmt.Println()
+	}
+}
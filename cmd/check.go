@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/check"
+	"LitTime/config"
+	"LitTime/estimator"
+	"LitTime/output"
+)
+
+func NewCheckCmd(cfg *config.Config) *cobra.Command {
+	var flags estimateFlags
+	var rules check.Rules
+	var formatName string
+	var outputPath string
+	var noCache bool
+
+	defaults := cfg.Check.Rules()
+
+	cmd := &cobra.Command{
+		Use:   "check <file>...",
+		Short: "Check documents against reading time and readability limits",
+		Long: `Estimate every file and check it against the rules from the check section of the
+config or from flags; a zero limit disables the rule. Violations are printed as
+file:line:column: message, or as JUnit XML or SARIF for CI systems.
+The command exits with code 3 if any file breaks a rule.`,
+		Example: `  littime check docs/*.md --max-time 10 --max-sentence-words 35
+  littime check README.md --min-flesch 40 --format sarif -o littime.sarif`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := check.ParseFormat(formatName)
+			if err != nil {
+				return usageError("%v", err)
+			}
+			if err := rules.Validate(); err != nil {
+				return usageError("%v", err)
+			}
+			if rules.Empty() {
+				return usageError("no rules to check: set limits in the check section of the config or with flags")
+			}
+			opts, _, err := buildOptions(cmd, cfg, flags)
+			if err != nil {
+				return err
+			}
+			// Показатели предложений и абзацев нужны только их правилам
+			opts.Detailed = rules.MaxSentenceWords > 0 || rules.MaxParagraphWords > 0
+			cmd.SilenceUsage = true
+
+			results := make([]check.FileResult, 0, len(args))
+			for _, path := range args {
+				text, err := estimator.ReadTextFromFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read file: %w", err)
+				}
+				result, err := estimateCached(cfg, text, opts, !noCache, false)
+				// Пустой файл — нарушение в этом файле, а не причина прерывать проверку остальных
+				if errors.Is(err, estimator.ErrEmptyText) {
+					results = append(results, check.CheckEmpty(path))
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+				results = append(results, check.Check(path, text, result, rules))
+			}
+
+			if err := writeCheck(outputPath, results, format, check.AllRules()); err != nil {
+				return err
+			}
+			if violations, files := check.Count(results); violations > 0 {
+				return &ExitError{Code: ExitLimit, Err: fmt.Errorf("found %d violation(s) in %d of %d files", violations, files, len(results))}
+			}
+			return nil
+		},
+	}
+
+	flags.addFlags(cmd, cfg, "the documents contain")
+	cmd.Flags().Float64Var(&rules.MaxReadingTime, "max-time", defaults.MaxReadingTime, "Maximum reading time of a file in minutes (0 disables)")
+	cmd.Flags().Float64Var(&rules.MinFlesch, "min-flesch", defaults.MinFlesch, "Minimum Flesch-Kincaid index of a file (0 disables)")
+	cmd.Flags().IntVar(&rules.MaxSentenceWords, "max-sentence-words", defaults.MaxSentenceWords, "Maximum words in a sentence (0 disables)")
+	cmd.Flags().IntVar(&rules.MaxParagraphWords, "max-paragraph-words", defaults.MaxParagraphWords, "Maximum words in a paragraph (0 disables)")
+	cmd.Flags().StringVar(&formatName, "format", string(check.FormatText), "Report format: "+strings.Join(check.Formats(), ", "))
+	cmd.Flags().StringVarP(&outputPath, "output", "o", output.Stdout, "Path to the report file, or \"-\" for stdout")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not read or write the result cache")
+
+	return cmd
+}
+
+func writeCheck(path string, results []check.FileResult, format check.Format, rules []check.RuleInfo) error {
+	if path == output.Stdout {
+		return check.WriteRules(os.Stdout, results, format, rules)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+	defer file.Close()
+	if err := check.WriteRules(file, results, format, rules); err != nil {
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to save report: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Report saved to: %s\n", path)
+	return nil
+}
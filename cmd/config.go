@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+)
+
+// Аннотация команд, которые работают и с неверной конфигурацией
+const configErrorsAllowed = "config-errors-allowed"
+
+// AddConfigFlag добавляет корневой команде флаг --config. Конфигурация загружается до разбора флагов,
+// поэтому значение флага читает из аргументов ConfigPath.
+func AddConfigFlag(root *cobra.Command) {
+	root.PersistentFlags().String("config", "", "Path to the config file (default: ./config.yaml or $XDG_CONFIG_HOME/littime/config.yaml)")
+}
+
+// ConfigPath возвращает значение флага --config из аргументов командной строки
+func ConfigPath(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if path, ok := strings.CutPrefix(arg, "--config="); ok {
+			return path
+		}
+		if arg == "--config" && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// CheckConfig возвращает ошибку загрузки конфигурации, если команде нужна верная конфигурация
+func CheckConfig(cmd *cobra.Command, err error) error {
+	if err == nil || cmd.Annotations[configErrorsAllowed] != "" {
+		return nil
+	}
+	cmd.SilenceUsage = true
+	return err
+}
+
+func NewConfigCmd(cfg *config.Config, loadErr error) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show or create the config file",
+	}
+	cmd.AddCommand(newConfigShowCmd(cfg, loadErr), newConfigInitCmd())
+	return cmd
+}
+
+func newConfigShowCmd(cfg *config.Config, loadErr error) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Print the effective config and the source of every value",
+		Long: `Print the effective config as YAML. The comment after every value tells where it
+comes from: the config file, a LITTIME_* environment variable or the built-in default.
+Exits with a non-zero code if the config is invalid.`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{configErrorsAllowed: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			file := cfg.File
+			if file == "" {
+				file = "none, searched " + strings.Join(config.SearchPaths(), ", ")
+			}
+			fmt.Printf("# Config file: %s\n", file)
+			if err := cfg.WriteEffective(os.Stdout); err != nil {
+				return fmt.Errorf("failed to print config: %w", err)
+			}
+			return loadErr
+		},
+	}
+}
+
+func newConfigInitCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Create a config file with the default values",
+		Long: `Create a commented config file with the default values. The file is written to the
+path from --config or LITTIME_CONFIG, or to $XDG_CONFIG_HOME/littime/config.yaml.`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{configErrorsAllowed: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cmd.SilenceUsage = true
+
+			path, _ := cmd.Flags().GetString("config")
+			if path == "" {
+				path = os.Getenv(config.PathEnv)
+			}
+			path, err := config.Init(path, force)
+			if errors.Is(err, fs.ErrExist) {
+				return fmt.Errorf("%w; use --force to overwrite it", err)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(os.Stderr, "Created config file: %s\n", path)
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&force, "force", false, "Overwrite an existing config file")
+
+	return cmd
+}
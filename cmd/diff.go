@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+	"LitTime/diff"
+	"LitTime/estimator"
+	"LitTime/output"
+)
+
+func NewDiffCmd(cfg *config.Config) *cobra.Command {
+	var flags estimateFlags
+	var threshold float64
+	var formatName string
+	var noCache bool
+
+	formats := make([]string, 0, len(diff.Formats()))
+	for _, format := range diff.Formats() {
+		formats = append(formats, string(format))
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff <old> <new>",
+		Short: "Compare reading time and readability of two versions of a document",
+		Long: `Estimate both files, match their paragraphs and report how the reading time,
+word count and readability metrics changed. Paragraphs whose Flesch-Kincaid index
+dropped or rose by at least --threshold points are reported as harder or easier.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := output.ParseFormat(formatName)
+			if err != nil {
+				return usageError("%v", err)
+			}
+			if !slices.Contains(diff.Formats(), format) {
+				return usageError("comparison cannot be written as %s (supported: %s)", format, strings.Join(formats, ", "))
+			}
+			if threshold < 0 {
+				return usageError("--threshold must not be negative")
+			}
+			opts, _, err := buildOptions(cmd, cfg, flags)
+			if err != nil {
+				return err
+			}
+			opts.Detailed = true
+			cmd.SilenceUsage = true
+
+			var results [2]*estimator.Result
+			for i, path := range args {
+				text, err := estimator.ReadTextFromFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read file: %w", err)
+				}
+				if results[i], err = estimateCached(cfg, text, opts, !noCache, false); err != nil {
+					return fmt.Errorf("%s: %w", path, err)
+				}
+			}
+
+			return diff.Write(os.Stdout, diff.Compare(results[0], results[1], threshold), format)
+		},
+	}
+
+	flags.addFlags(cmd, cfg, "the texts contain")
+	cmd.Flags().Float64Var(&threshold, "threshold", diff.DefaultThreshold, "Flesch-Kincaid change that makes a paragraph harder or easier")
+	cmd.Flags().StringVar(&formatName, "format", string(output.FormatText), "Comparison format: "+strings.Join(formats, ", "))
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not read or write the result cache")
+
+	return cmd
+}
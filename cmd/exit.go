@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Коды завершения программы
+const (
+	ExitOK      = 0 // Успешное выполнение
+	ExitFailure = 1 // Ошибка во время оценки или сохранения результата
+	ExitUsage   = 2 // Неверные флаги или аргументы командной строки
+	ExitLimit   = 3 // Документы не прошли проверку, например превышен --max-delta
+)
+
+// ExitError связывает ошибку с кодом завершения процесса
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// usageError оборачивает ошибку неверного использования команды
+func usageError(format string, args ...any) error {
+	return &ExitError{Code: ExitUsage, Err: fmt.Errorf(format, args...)}
+}
+
+// ExitCode возвращает код завершения для ошибки, которую вернула команда
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+	return ExitFailure
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+	"LitTime/estimator"
+	"LitTime/gitdelta"
+	"LitTime/output"
+)
+
+func NewGitCmd(cfg *config.Config) *cobra.Command {
+	var flags estimateFlags
+	var repoDir string
+	var patterns []string
+	var maxDelta float64
+	var formatName string
+	var noCache bool
+
+	formats := make([]string, 0, len(gitdelta.Formats()))
+	for _, format := range gitdelta.Formats() {
+		formats = append(formats, string(format))
+	}
+
+	cmd := &cobra.Command{
+		Use:   "git <rev-range>",
+		Short: "Show how commits change the reading time of documentation",
+		Long: `Read documentation files from the local git repository at every commit of the range
+and report how each commit changed their reading time. The range is given as in git log:
+"main..HEAD" lists the commits of the current branch, a single revision shows one commit.
+The total is counted from the common ancestor, as in a pull request.
+
+With --max-delta the command exits with code 3 when the total grows by more than
+the given number of minutes, which lets CI reject documentation PRs that are too long.`,
+		Example: `  littime git main..HEAD
+  littime git origin/main.. --max-delta 5 --include 'docs/*.md'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := output.ParseFormat(formatName)
+			if err != nil {
+				return usageError("%v", err)
+			}
+			if !slices.Contains(gitdelta.Formats(), format) {
+				return usageError("report cannot be written as %s (supported: %s)", format, strings.Join(formats, ", "))
+			}
+			if maxDelta < 0 {
+				return usageError("--max-delta must not be negative")
+			}
+			if len(patterns) == 0 {
+				return usageError("--include must not be empty")
+			}
+			opts, _, err := buildOptions(cmd, cfg, flags)
+			if err != nil {
+				return err
+			}
+
+			analyzer, err := gitdelta.Open(repoDir, patterns, func(text string) (*estimator.Result, error) {
+				return estimateCached(cfg, text, opts, !noCache, false)
+			})
+			if err != nil {
+				return usageError("%v", err)
+			}
+			report, err := analyzer.Analyze(args[0])
+			if errors.Is(err, gitdelta.ErrInvalidRange) {
+				return usageError("%v", err)
+			}
+			cmd.SilenceUsage = true
+			if err != nil {
+				return err
+			}
+
+			if err := gitdelta.Write(os.Stdout, report, format); err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("max-delta") && report.Total > maxDelta {
+				return &ExitError{Code: ExitLimit, Err: fmt.Errorf("reading time grew by %.2f min, more than --max-delta %g", report.Total, maxDelta)}
+			}
+			return nil
+		},
+	}
+
+	flags.addFlags(cmd, cfg, "the documents contain")
+	cmd.Flags().StringVarP(&repoDir, "repo", "C", ".", "Directory inside the git repository")
+	cmd.Flags().StringSliceVar(&patterns, "include", gitdelta.DefaultPatterns, "Glob patterns of documentation files; patterns with \"/\" match the whole path")
+	cmd.Flags().Float64Var(&maxDelta, "max-delta", 0, "Fail with exit code 3 if the total reading time grows by more than this many minutes")
+	cmd.Flags().StringVar(&formatName, "format", string(output.FormatText), "Report format: "+strings.Join(formats, ", "))
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not read or write the result cache")
+
+	return cmd
+}
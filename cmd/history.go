@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+	"LitTime/estimator"
+	"LitTime/history"
+	"LitTime/output"
+)
+
+func NewHistoryCmd(cfg *config.Config) *cobra.Command {
+	var limit int
+
+	cmd := &cobra.Command{
+		Use:   "history [file]",
+		Short: "List past estimates or show how a document changed over time",
+		Long: `Without arguments, list the documents in the history with their latest estimate.
+With a file, show every estimate of that file and how its reading time, readability
+and word count changed since the previous run.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if limit < 0 {
+				return usageError("--limit must not be negative")
+			}
+			cmd.SilenceUsage = true
+
+			store, err := openHistory(cfg)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			if len(args) == 0 {
+				documents, err := store.Documents()
+				if errors.Is(err, history.ErrNoHistory) {
+					fmt.Fprintln(os.Stderr, "History is empty")
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				// Документы упорядочены от недавно оцененных
+				if limit > 0 {
+					documents = documents[:min(limit, len(documents))]
+				}
+				return history.WriteDocuments(os.Stdout, documents)
+			}
+
+			entries, err := documentHistory(store, args[0])
+			if err != nil {
+				return err
+			}
+			if limit > 0 {
+				entries = entries[max(len(entries)-limit, 0):]
+			}
+			return history.WriteTrend(os.Stdout, entries)
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", 0, "Show only the latest N entries (0 shows all)")
+	cmd.AddCommand(newHistoryExportCmd(cfg))
+
+	return cmd
+}
+
+func newHistoryExportCmd(cfg *config.Config) *cobra.Command {
+	var formatName string
+	var outputPath string
+
+	formats := make([]string, 0, len(history.ExportFormats()))
+	for _, format := range history.ExportFormats() {
+		formats = append(formats, string(format))
+	}
+
+	cmd := &cobra.Command{
+		Use:   "export [file]",
+		Short: "Export the history of all documents or of one file",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := output.ParseFormat(formatName)
+			if err != nil {
+				return usageError("%v", err)
+			}
+			if !slices.Contains(history.ExportFormats(), format) {
+				return usageError("history cannot be exported as %s (supported: %s)", format, strings.Join(formats, ", "))
+			}
+			cmd.SilenceUsage = true
+
+			store, err := openHistory(cfg)
+			if err != nil {
+				return err
+			}
+			defer store.Close()
+
+			var entries []history.Entry
+			if len(args) == 0 {
+				entries, err = store.Entries("")
+				if errors.Is(err, history.ErrNoHistory) {
+					entries, err = []history.Entry{}, nil
+				}
+			} else {
+				entries, err = documentHistory(store, args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			if outputPath == output.Stdout {
+				return history.Export(os.Stdout, entries, format)
+			}
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to export history: %w", err)
+			}
+			defer file.Close()
+			if err := history.Export(file, entries, format); err != nil {
+				return fmt.Errorf("failed to export history: %w", err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to export history: %w", err)
+			}
+			fmt.Fprintf(os.Stderr, "History exported to: %s\n", outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&formatName, "format", string(output.FormatJSON), "Export format: "+strings.Join(formats, ", "))
+	cmd.Flags().StringVarP(&outputPath, "output", "o", output.Stdout, "Path to the export file, or \"-\" for stdout")
+
+	return cmd
+}
+
+func openHistory(cfg *config.Config) (*history.Store, error) {
+	path := cfg.HistoryFile
+	if path == "" {
+		var err error
+		if path, err = history.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	return history.Open(path)
+}
+
+// documentHistory возвращает записи файла; путь приводится к абсолютному, как при сохранении
+func documentHistory(store *history.Store, path string) ([]history.Entry, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return store.Entries(abs)
+}
+
+// recordHistory сохраняет оценку в историю. История — вспомогательная функция,
+// поэтому ошибка не прерывает команду, а выводится предупреждением.
+func recordHistory(cfg *config.Config, path, text string, result *estimator.Result, opts estimator.Options, profile string, quiet bool) {
+	if !cfg.History {
+		return
+	}
+	err := func() error {
+		entry, err := history.NewEntry(path, text, result, opts)
+		if err != nil {
+			return err
+		}
+		entry.Profile = profile
+
+		store, err := openHistory(cfg)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		return store.Add(entry)
+	}()
+	if err != nil && !quiet {
+		fmt.Fprintf(os.Stderr, "Warning: failed to save history: %v\n", err)
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/check"
+	"LitTime/config"
+	"LitTime/estimator"
+	"LitTime/output"
+	"LitTime/style"
+)
+
+func NewLintCmd(cfg *config.Config) *cobra.Command {
+	var syllables string
+	var disabled []string
+	var formatName string
+	var outputPath string
+
+	// Ошибки раздела style уже выведены при загрузке конфигурации
+	opts, err := cfg.Style.Options()
+	if err != nil {
+		opts = style.DefaultOptions()
+	}
+	defaultDisabled := make([]string, 0, len(opts.Disabled))
+	for _, kind := range opts.Disabled {
+		defaultDisabled = append(defaultDisabled, string(kind))
+	}
+	kinds := make([]string, 0, len(style.Kinds()))
+	for _, kind := range style.Kinds() {
+		kinds = append(kinds, string(kind))
+	}
+
+	cmd := &cobra.Command{
+		Use:   "lint <file>...",
+		Short: "Point out long sentences, complex words, passive voice, adverbs and repeated words",
+		Long: `Analyze the writing style of every file and print each finding with its position,
+as file:line:column: message, or as JUnit XML or SARIF. Thresholds come from the style
+section of the config or from flags. The command exits with code 3 if anything is found.
+
+Checks: ` + strings.Join(kinds, ", ") + `.`,
+		Example: `  littime lint README.md
+  littime lint docs/*.md --max-sentence-words 20 --disable repeated-word`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := check.ParseFormat(formatName)
+			if err != nil {
+				return usageError("%v", err)
+			}
+			if opts.Syllables, err = estimator.ParseSyllableBackend(syllables); err != nil {
+				return usageError("%v", err)
+			}
+			opts.Disabled = opts.Disabled[:0]
+			for _, name := range disabled {
+				kind, err := style.ParseKind(name)
+				if err != nil {
+					return usageError("%v", err)
+				}
+				opts.Disabled = append(opts.Disabled, kind)
+			}
+			if err := opts.Validate(); err != nil {
+				return usageError("%v", err)
+			}
+			cmd.SilenceUsage = true
+
+			results := make([]check.FileResult, 0, len(args))
+			for _, path := range args {
+				text, err := estimator.ReadTextFromFile(path)
+				if err != nil {
+					return fmt.Errorf("failed to read file: %w", err)
+				}
+				result := check.FileResult{File: path}
+				for _, issue := range style.Analyze(text, opts) {
+					result.Violations = append(result.Violations, check.Violation{
+						File: path, Line: issue.Line, Column: issue.Column, Rule: check.Rule(issue.Kind), Message: issue.Message,
+					})
+				}
+				results = append(results, result)
+			}
+
+			rules := make([]check.RuleInfo, 0, len(style.Kinds()))
+			for _, kind := range style.Kinds() {
+				rules = append(rules, check.RuleInfo{ID: check.Rule(kind), Description: kind.Description()})
+			}
+			if err := writeCheck(outputPath, results, format, rules); err != nil {
+				return err
+			}
+			if issues, files := check.Count(results); issues > 0 {
+				return &ExitError{Code: ExitLimit, Err: fmt.Errorf("found %d style issue(s) in %d of %d files", issues, files, len(results))}
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.MaxSentenceWords, "max-sentence-words", opts.MaxSentenceWords, "Flag sentences with more words")
+	cmd.Flags().IntVar(&opts.MaxSyllables, "max-syllables", opts.MaxSyllables, "Flag words with more syllables")
+	cmd.Flags().Float64Var(&opts.MaxAdverbShare, "max-adverbs", opts.MaxAdverbShare, "Flag paragraphs where adverbs make up a larger share of words (0 disables)")
+	cmd.Flags().IntVar(&opts.RepeatWindow, "repeat-window", opts.RepeatWindow, "Flag words repeated within this many words")
+	cmd.Flags().StringSliceVar(&disabled, "disable", defaultDisabled, "Checks to skip: "+strings.Join(kinds, ", "))
+	cmd.Flags().StringVar(&syllables, "syllables", cfg.SyllableBackend, "Syllable counting backend: heuristic or patterns")
+	cmd.Flags().StringVar(&formatName, "format", string(check.FormatText), "Report format: "+strings.Join(check.Formats(), ", "))
+	cmd.Flags().StringVarP(&outputPath, "output", "o", output.Stdout, "Path to the report file, or \"-\" for stdout")
+
+	return cmd
+}
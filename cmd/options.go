@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+	"LitTime/estimator"
+)
+
+// estimateFlags — флаги оценки, общие для команд, которые оценивают текст
+type estimateFlags struct {
+	readingSpeed int
+	hasVisuals   bool
+	workers      int
+	syllables    string
+	mode         string
+}
+
+// addFlags добавляет команде флаги оценки; visuals — то, о чем спрашивает --visuals
+func (f *estimateFlags) addFlags(cmd *cobra.Command, cfg *config.Config, visuals string) {
+	cmd.Flags().IntVarP(&f.readingSpeed, "speed", "s", cfg.DefaultReadingSpeed, "Reading speed in words per minute")
+	cmd.Flags().BoolVarP(&f.hasVisuals, "visuals", "v", false, "Set to true if "+visuals+" visual elements")
+	cmd.Flags().IntVarP(&f.workers, "workers", "w", cfg.DefaultWorkers, "Number of worker goroutines")
+	cmd.Flags().StringVar(&f.syllables, "syllables", cfg.SyllableBackend, "Syllable counting backend: heuristic or patterns")
+	cmd.Flags().StringVar(&f.mode, "mode", cfg.ReadingMode, "Reading mode: skim, normal, study or aloud")
+}
+
+// buildOptions собирает параметры оценщика из флагов, профиля читателя и конфигурации
+// и проверяет их. Возвращает также профиль, выбранный флагом --profile.
+func buildOptions(cmd *cobra.Command, cfg *config.Config, flags estimateFlags) (estimator.Options, config.Profile, error) {
+	backend, err := estimator.ParseSyllableBackend(flags.syllables)
+	if err != nil {
+		return estimator.Options{}, config.Profile{}, usageError("%v", err)
+	}
+	mode, err := estimator.ParseReadingMode(flags.mode)
+	if err != nil {
+		return estimator.Options{}, config.Profile{}, usageError("%v", err)
+	}
+	profile, err := resolveProfile(cmd, cfg)
+	if err != nil {
+		return estimator.Options{}, config.Profile{}, err
+	}
+	// Скорость из флага важнее скорости из профиля, в том числе скоростей по языкам
+	if cmd.Flags().Changed("speed") {
+		profile = profile.WithReadingSpeed(flags.readingSpeed)
+	}
+
+	policies, err := cfg.TokenClassPolicies()
+	if err != nil {
+		cmd.SilenceUsage = true
+		return estimator.Options{}, config.Profile{}, fmt.Errorf("invalid token_classes config: %w", err)
+	}
+	opts := estimator.Options{
+		ReadingSpeed: float64(profile.ReadingSpeed),
+		HasVisuals:   flags.hasVisuals,
+		Workers:      flags.workers,
+		Syllables:    backend,
+		Mode:         mode,
+
+		Profile:             profile.Estimator(),
+		ClassPolicies:       policies,
+		CharactersPerMinute: float64(cfg.CharactersPerMinute),
+	}
+	if err := opts.Validate(); err != nil {
+		return estimator.Options{}, config.Profile{}, usageError("%v", err)
+	}
+	return opts, profile, nil
+}
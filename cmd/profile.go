@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+)
+
+// AddProfileFlag добавляет корневой команде флаг --profile, общий для всех подкоманд
+func AddProfileFlag(root *cobra.Command) {
+	root.PersistentFlags().String("profile", "", "Reader profile from the config (default: default_profile)")
+}
+
+// resolveProfile возвращает профиль, выбранный флагом --profile
+func resolveProfile(cmd *cobra.Command, cfg *config.Config) (config.Profile, error) {
+	name, _ := cmd.Flags().GetString("profile")
+	profile, err := cfg.ResolveProfile(name)
+	if errors.Is(err, config.ErrUnknownProfile) {
+		return config.Profile{}, usageError("%v", err)
+	}
+	if err != nil {
+		// Ошибка в файле конфигурации, а не во флагах
+		cmd.SilenceUsage = true
+		return config.Profile{}, err
+	}
+	return profile, nil
+}
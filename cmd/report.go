@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"LitTime/config"
+	"LitTime/estimator"
+	"LitTime/output"
+	"LitTime/report"
+)
+
+func NewReportCmd(cfg *config.Config) *cobra.Command {
+	var filePath string
+	var flags estimateFlags
+	var outputPath string
+	var hardest int
+	var compareModes bool
+	var noHistory bool
+	var noCache bool
+
+	cmd := &cobra.Command{
+		Use:   "report",
+		Short: "Render a self-contained HTML report for a text file",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if filePath == "" {
+				return usageError("required flag(s) \"file\" not set")
+			}
+			if hardest < 0 {
+				return usageError("--hardest must not be negative")
+			}
+			estimateOpts, profile, err := buildOptions(cmd, cfg, flags)
+			if err != nil {
+				return err
+			}
+			estimateOpts.CompareModes = compareModes
+			cmd.SilenceUsage = true
+
+			text, err := estimator.ReadTextFromFile(filePath)
+			if err != nil {
+				return fmt.Errorf("failed to read file: %w", err)
+			}
+			result, err := estimateCached(cfg, text, estimateOpts, !noCache, false)
+			if err != nil {
+				return err
+			}
+			if !noHistory {
+				recordHistory(cfg, filePath, text, result, estimateOpts, profile.Name, false)
+			}
+
+			opts := report.Options{
+				Title:    filepath.Base(filePath),
+				Hardest:  hardest,
+				Estimate: estimateOpts,
+			}
+			if outputPath == output.Stdout {
+				return report.Write(os.Stdout, text, result, opts)
+			}
+
+			file, err := os.Create(outputPath)
+			if err != nil {
+				return fmt.Errorf("failed to save report: %w", err)
+			}
+			defer file.Close()
+
+			if err := report.Write(file, text, result, opts); err != nil {
+				return fmt.Errorf("failed to render report: %w", err)
+			}
+			if err := file.Close(); err != nil {
+				return fmt.Errorf("failed to save report: %w", err)
+			}
+
+			fmt.Fprintf(os.Stderr, "Report saved to: %s\n", outputPath)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the text file")
+	flags.addFlags(cmd, cfg, "the text contains")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "littime_report.html", "Path to the HTML report, or \"-\" for stdout")
+	cmd.Flags().IntVar(&hardest, "hardest", 5, "Number of hardest sentences to highlight")
+	cmd.Flags().BoolVar(&compareModes, "compare-modes", false, "Include the reading time of every mode in the result")
+	cmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not save the estimate to the history")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not read or write the result cache")
+
+	return cmd
+}
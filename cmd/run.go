@@ -1,97 +1,155 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/spf13/cobra"
 	"os"
+	"strings"
 
 	"LitTime/config"
 	"LitTime/estimator"
+	"LitTime/output"
 	"LitTime/ui"
 )
 
 func NewRunCmd(cfg *config.Config) *cobra.Command {
 	var filePath string
-	var readingSpeed int
-	var hasVisuals bool
-	var workers int
+	var flags estimateFlags
 	var interactive bool
+	var outputPath string
+	var formatName string
+	var noTUI bool
+	var quiet bool
+	var detailed bool
+	var hardest int
+	var compareModes bool
+	var vocabulary bool
+	var cefr bool
+	var noHistory bool
+	var noCache bool
 
 	cmd := &cobra.Command{
 		Use:   "run",
 		Short: "Run the LitTime estimator",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			format, err := output.ParseFormat(formatName)
+			if err != nil {
+				return usageError("%v", err)
+			}
+
+			if hardest < 0 {
+				return usageError("--hardest must not be negative")
+			}
+			opts, profile, err := buildOptions(cmd, cfg, flags)
+			if err != nil {
+				return err
+			}
+
+			// Без терминала полноэкранный интерфейс не запускаем, чтобы не зависать в CI
+			headless := noTUI || quiet || !ui.IsTerminal()
+			if interactive && !ui.IsTerminal() {
+				return usageError("interactive mode requires a terminal")
+			}
+
 			// Если интерактивный режим включен, запускаем интерфейс через bubbletea
 			if interactive {
-				userInputs, err := ui.RunInteractive(cfg)
+				formCfg := *cfg
+				formCfg.DefaultReadingSpeed = int(opts.ReadingSpeed)
+				userInputs, err := ui.RunInteractive(&formCfg)
 				if err != nil {
 					return err
 				}
 				filePath = userInputs.FilePath
-				readingSpeed = userInputs.ReadingSpeed
-				hasVisuals = userInputs.HasVisuals
-				workers = userInputs.Workers
+				// Измененная в форме скорость задана явно, как флагом --speed
+				if speed := float64(userInputs.ReadingSpeed); speed != opts.ReadingSpeed {
+					opts.ReadingSpeed = speed
+					opts.Profile = profile.WithReadingSpeed(userInputs.ReadingSpeed).Estimator()
+				}
+				opts.HasVisuals = userInputs.HasVisuals
+				opts.Workers = userInputs.Workers
 			}
 
 			// Проверяем, если интерактивный режим выключен, то файл должен быть указан через флаг
 			if !interactive && filePath == "" {
-				return fmt.Errorf("required flag(s) \"file\" not set")
+				return usageError("required flag(s) \"file\" not set")
 			}
 
 			// Проверяем, был ли передан валидный путь к файлу
 			if filePath == "" {
-				return fmt.Errorf("file path cannot be empty")
+				return usageError("file path cannot be empty")
+			}
+
+			opts.Detailed = detailed
+			opts.Hardest = hardest
+			opts.CompareModes = compareModes
+			opts.Vocabulary = vocabulary
+			opts.CEFR = cefr
+
+			// Дальнейшие ошибки не связаны с флагами, справку по ним не выводим
+			cmd.SilenceUsage = true
+
+			if vocabulary {
+				if opts.FrequencyLists, err = cfg.FrequencyLists(); err != nil {
+					return err
+				}
 			}
 
 			// Запуск оценки времени чтения
-			result, err := runEstimator(filePath, readingSpeed, hasVisuals, workers)
+			text, err := estimator.ReadTextFromFile(filePath)
+			if err != nil {
+				return fmt.Errorf("failed to read file: %w", err)
+			}
+			result, err := estimateCached(cfg, text, opts, !noCache, quiet)
 			if err != nil {
 				return err
 			}
+			if !noHistory {
+				recordHistory(cfg, filePath, text, result, opts, profile.Name, quiet)
+			}
 
-			fmt.Printf("Saving result to: %s\n", cfg.OutputFile)
-			if err := saveResult(result, cfg.OutputFile); err != nil {
+			if outputPath != output.Stdout && !quiet {
+				fmt.Fprintf(os.Stderr, "Saving result to: %s\n", outputPath)
+			}
+			if err := output.WriteFile(outputPath, result, format); err != nil {
 				return fmt.Errorf("failed to save result: %w", err)
 			}
 
-			return ui.RunUI(result)
+			if !headless {
+				return ui.RunUI(result)
+			}
+
+			// Результат уже выведен в stdout, краткая сводка его бы испортила
+			if !quiet && outputPath != output.Stdout {
+				fmt.Println(output.Summary(filePath, result))
+			}
+			return nil
 		},
 	}
 
 	cmd.Flags().StringVarP(&filePath, "file", "f", "", "Path to the text file")
-	cmd.Flags().IntVarP(&readingSpeed, "speed", "s", cfg.DefaultReadingSpeed, "Reading speed in words per minute")
-	cmd.Flags().BoolVarP(&hasVisuals, "visuals", "v", false, "Set to true if the text contains visual elements")
-	cmd.Flags().IntVarP(&workers, "workers", "w", cfg.DefaultWorkers, "Number of worker goroutines")
+	flags.addFlags(cmd, cfg, "the text contains")
 	cmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Enable interactive mode for setting options")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", cfg.OutputFile, "Path to the result file, or \"-\" for stdout")
+	cmd.Flags().BoolVar(&noTUI, "no-tui", false, "Print a short summary instead of starting the results interface")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Print nothing but errors; implies --no-tui")
+	cmd.Flags().BoolVar(&detailed, "details", false, "Include per-paragraph and per-sentence statistics in the result")
+	cmd.Flags().IntVar(&hardest, "hardest", 0, "Number of hardest sentences to include in the result (0 skips sentence analysis)")
+	cmd.Flags().BoolVar(&compareModes, "compare-modes", false, "Include the reading time of every mode in the result")
+	cmd.Flags().BoolVar(&vocabulary, "vocabulary", false, "Include vocabulary statistics per language in the result")
+	cmd.Flags().BoolVar(&cefr, "cefr", false, "Include an approximate CEFR level (A1-C2) of English and Russian text in the result")
+	cmd.Flags().BoolVar(&noHistory, "no-history", false, "Do not save the estimate to the history")
+	cmd.Flags().BoolVar(&noCache, "no-cache", false, "Do not read or write the result cache")
+	cmd.Flags().StringVar(&formatName, "format", cfg.OutputFormat, "Result format: "+strings.Join(output.Formats(), ", "))
 
 	return cmd
 }
 
-func runEstimator(filePath string, readingSpeed int, hasVisuals bool, workers int) (*estimator.Result, error) {
-	// Чтение текста из файла
-	text, err := estimator.ReadTextFromFile(filePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read file: %w", err)
-	}
-
+func estimateText(text string, opts estimator.Options) (*estimator.Result, error) {
 	// Оценка времени чтения
-	result, err := estimator.EstimateReadingTimeParallel(text, float64(readingSpeed), hasVisuals, workers)
+	result, err := estimator.Estimate(text, opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to estimate reading time: %w", err)
 	}
 
 	return &result, nil
 }
-
-func saveResult(result *estimator.Result, outputFile string) error {
-	file, err := os.Create(outputFile)
-	if err != nil {
-		return err
-	}
-	defer file.Close()
-
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(result)
-}
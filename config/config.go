@@ -1,45 +1,640 @@
 package config
 
 import (
+	"bytes"
+	_ "embed"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
 	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"LitTime/check"
+	"LitTime/estimator"
+	"LitTime/output"
+	"LitTime/style"
 )
 
+// EnvPrefix — префикс переменных окружения, которые переопределяют настройки, например LITTIME_DEFAULT_WORKERS
+const EnvPrefix = "LITTIME"
+
+// PathEnv — переменная окружения с путем к файлу конфигурации, если он не задан флагом --config
+const PathEnv = EnvPrefix + "_CONFIG"
+
+// Шаблон файла конфигурации для команды config init
+//
+//go:embed default.yaml
+var configTemplate []byte
+
+// Значения по умолчанию; они же записаны в default.yaml
+var defaults = map[string]any{
+	"default_reading_speed": 180,
+	"default_workers":       4,
+	"output_file":           "littime_results.json",
+	"output_format":         "json",
+	"syllable_backend":      "heuristic",
+	"reading_mode":          "normal",
+	"characters_per_minute": 0,
+	"default_profile":       "",
+	"history":               true,
+	"history_file":          "",
+	"cache":                 true,
+	"cache_dir":             "",
+	"cache_max_size":        50,
+}
+
 type Config struct {
 	DefaultReadingSpeed int    `mapstructure:"default_reading_speed"`
 	DefaultWorkers      int    `mapstructure:"default_workers"`
 	OutputFile          string `mapstructure:"output_file"`
+	OutputFormat        string `mapstructure:"output_format"`
+	SyllableBackend     string `mapstructure:"syllable_backend"`
+	ReadingMode         string `mapstructure:"reading_mode"`
+	CharactersPerMinute int    `mapstructure:"characters_per_minute"` // Для китайского и японского, 0 — по языку
+
+	// Скорость чтения по языкам
+	LanguageSpeeds map[string]int `mapstructure:"language_speeds"`
+
+	// Профили читателей, выбираются флагом --profile
+	DefaultProfile string                   `mapstructure:"default_profile"`
+	Profiles       map[string]ProfileConfig `mapstructure:"profiles"`
+
+	// Правила для видов токенов (word, number, url, email, identifier, abbreviation, emoji)
+	TokenClasses map[string]TokenClassConfig `mapstructure:"token_classes"`
+
+	// История оценок; пустой history_file означает $XDG_DATA_HOME/littime/history.db
+	History     bool   `mapstructure:"history"`
+	HistoryFile string `mapstructure:"history_file"`
+
+	// Кэш результатов; пустой cache_dir означает $XDG_CACHE_HOME/littime
+	Cache        bool   `mapstructure:"cache"`
+	CacheDir     string `mapstructure:"cache_dir"`
+	CacheMaxSize int    `mapstructure:"cache_max_size"` // Предельный размер в мегабайтах, 0 — без ограничения
+
+	// Правила команды check
+	Check CheckRules `mapstructure:"check"`
+
+	// Пороги команды lint
+	Style StyleConfig `mapstructure:"style"`
+
+	// Частотные списки для показателей словарного разнообразия
+	Vocabulary VocabularyConfig `mapstructure:"vocabulary"`
+
+	File    string            `mapstructure:"-"` // Прочитанный файл конфигурации, пустой, если файла нет
+	sources map[string]Source // Источник значения каждой настройки
+}
+
+// TokenClassConfig переопределяет стоимость чтения и учет слогов для вида токенов.
+// Незаданные поля остаются значениями по умолчанию.
+type TokenClassConfig struct {
+	Cost      *float64 `mapstructure:"cost" yaml:"cost,omitempty"`
+	Syllables string   `mapstructure:"syllables" yaml:"syllables,omitempty"`
+}
+
+// CheckRules — пороги команды check; нулевое значение отключает правило
+type CheckRules struct {
+	MaxReadingTime    float64 `mapstructure:"max_reading_time" yaml:"max_reading_time"` // В минутах на файл
+	MinFlesch         float64 `mapstructure:"min_flesch" yaml:"min_flesch"`             // Индекс Флеша-Кинкейда файла
+	MaxSentenceWords  int     `mapstructure:"max_sentence_words" yaml:"max_sentence_words"`
+	MaxParagraphWords int     `mapstructure:"max_paragraph_words" yaml:"max_paragraph_words"`
+}
+
+// Rules возвращает правила в виде, который принимает пакет check
+func (r CheckRules) Rules() check.Rules {
+	return check.Rules{
+		MaxReadingTime:    r.MaxReadingTime,
+		MinFlesch:         r.MinFlesch,
+		MaxSentenceWords:  r.MaxSentenceWords,
+		MaxParagraphWords: r.MaxParagraphWords,
+	}
+}
+
+// StyleConfig — пороги анализа стиля; нулевое значение означает порог по умолчанию
+type StyleConfig struct {
+	MaxSentenceWords int      `mapstructure:"max_sentence_words" yaml:"max_sentence_words,omitempty"`
+	MaxSyllables     int      `mapstructure:"max_syllables" yaml:"max_syllables,omitempty"`
+	MaxAdverbShare   float64  `mapstructure:"max_adverb_share" yaml:"max_adverb_share,omitempty"`
+	RepeatWindow     int      `mapstructure:"repeat_window" yaml:"repeat_window,omitempty"`
+	Disable          []string `mapstructure:"disable" yaml:"disable,omitempty"` // Отключенные виды замечаний
+}
+
+// Options возвращает пороги анализа стиля, дополненные значениями по умолчанию
+func (s StyleConfig) Options() (style.Options, error) {
+	opts := style.DefaultOptions()
+	if s.MaxSentenceWords != 0 {
+		opts.MaxSentenceWords = s.MaxSentenceWords
+	}
+	if s.MaxSyllables != 0 {
+		opts.MaxSyllables = s.MaxSyllables
+	}
+	if s.MaxAdverbShare != 0 {
+		opts.MaxAdverbShare = s.MaxAdverbShare
+	}
+	if s.RepeatWindow != 0 {
+		opts.RepeatWindow = s.RepeatWindow
+	}
+	for _, name := range s.Disable {
+		kind, err := style.ParseKind(name)
+		if err != nil {
+			return style.Options{}, err
+		}
+		opts.Disabled = append(opts.Disabled, kind)
+	}
+	return opts, opts.Validate()
+}
+
+// VocabularyConfig — частотные списки слов по языкам. Относительные пути отсчитываются
+// от каталога файла конфигурации.
+type VocabularyConfig struct {
+	FrequencyLists    map[string]string `mapstructure:"frequency_lists" yaml:"frequency_lists,omitempty"`
+	FrequencyListSize int               `mapstructure:"frequency_list_size" yaml:"frequency_list_size"` // Первые слова списка, 0 — весь список
+}
+
+// FrequencyLists читает частотные списки из vocabulary.frequency_lists
+func (c *Config) FrequencyLists() (map[string][]string, error) {
+	lists := make(map[string][]string, len(c.Vocabulary.FrequencyLists))
+	for language, path := range c.Vocabulary.FrequencyLists {
+		if !filepath.IsAbs(path) && c.File != "" {
+			path = filepath.Join(filepath.Dir(c.File), path)
+		}
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read frequency list for %s: %w", language, err)
+		}
+		words, err := estimator.ReadFrequencyList(file, c.Vocabulary.FrequencyListSize)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read frequency list for %s: %w", language, err)
+		}
+		lists[language] = words
+	}
+	return lists, nil
+}
+
+// Виды источников значений настроек
+const (
+	SourceDefault = "default"
+	SourceFile    = "file"
+	SourceEnv     = "env"
+)
+
+// Source описывает, откуда взято значение настройки
+type Source struct {
+	Kind string // SourceDefault, SourceFile или SourceEnv
+	Name string // Путь к файлу или имя переменной окружения
+}
+
+func (s Source) String() string {
+	if s.Name == "" {
+		return s.Kind
+	}
+	return s.Kind + " " + s.Name
 }
 
-// LoadConfig загружает конфигурацию из файла config.yaml или использует значения по умолчанию.
-func LoadConfig() (*Config, error) {
-	viper.SetConfigName("config")                                   // Имя конфигурационного файла (без расширения)
-	viper.SetConfigType("yaml")                                     // Тип файла конфигурации
-	viper.AddConfigPath(".")                                        // Текущая директория
-	viper.AddConfigPath("/Users/daniilsolovey/Program/go/LitTime/") // Ваша конкретная директория
+// ValidationError перечисляет все неверные значения в конфигурации
+type ValidationError struct {
+	Problems []string
+}
 
-	// Установим значения по умолчанию
-	viper.SetDefault("default_reading_speed", 180)
-	viper.SetDefault("default_workers", 4)
-	viper.SetDefault("output_file", "littime_results.json")
+func (e *ValidationError) Error() string {
+	return "invalid config:\n  " + strings.Join(e.Problems, "\n  ")
+}
 
-	// Попытаемся прочитать конфигурацию из файла
-	err := viper.ReadInConfig()
+// Dir возвращает каталог конфигурации пользователя по спецификации XDG:
+// $XDG_CONFIG_HOME/littime или ~/.config/littime
+func Dir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "littime"), nil
+	}
+	home, err := os.UserHomeDir()
 	if err != nil {
-		// Если конфиг не найден, это не ошибка, будем использовать значения по умолчанию
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			fmt.Println("Конфигурационный файл не найден, используются значения по умолчанию")
-		} else {
-			// Возвращаем ошибку, если произошла другая ошибка при чтении файла
-			return nil, fmt.Errorf("не удалось прочитать конфигурационный файл: %w", err)
+		return "", fmt.Errorf("failed to find the config directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "littime"), nil
+}
+
+// SearchPaths возвращает каталоги, в которых ищется config.yaml, в порядке приоритета:
+// текущий каталог, каталог пользователя и системные каталоги из $XDG_CONFIG_DIRS
+func SearchPaths() []string {
+	paths := []string{"."}
+	if dir, err := Dir(); err == nil {
+		paths = append(paths, dir)
+	}
+	systemDirs := os.Getenv("XDG_CONFIG_DIRS")
+	if systemDirs == "" {
+		systemDirs = "/etc/xdg"
+	}
+	for _, dir := range filepath.SplitList(systemDirs) {
+		if filepath.IsAbs(dir) {
+			paths = append(paths, filepath.Join(dir, "littime"))
+		}
+	}
+	return paths
+}
+
+// LoadConfig загружает конфигурацию и проверяет ее значения. Файл берется из path, затем из переменной
+// LITTIME_CONFIG, а если оба пусты — ищется config.yaml в SearchPaths; без файла используются значения
+// по умолчанию. Переменные окружения LITTIME_* важнее значений из файла.
+//
+// При ошибке возвращается и конфигурация из тех значений, которые удалось прочитать,
+// чтобы команды config show и config init могли работать с неверным файлом.
+func LoadConfig(path string) (*Config, error) {
+	viper.Reset()
+	for key, value := range defaults {
+		viper.SetDefault(key, value)
+		// Переменные окружения поддерживаются только для простых значений, разделы задаются в файле
+		_ = viper.BindEnv(key, envName(key))
+	}
+
+	if path == "" {
+		path = os.Getenv(PathEnv)
+	}
+	if path != "" {
+		// Явно указанный файл должен существовать
+		viper.SetConfigFile(path)
+	} else {
+		viper.SetConfigName("config")
+		viper.SetConfigType("yaml")
+		for _, dir := range SearchPaths() {
+			viper.AddConfigPath(dir)
+		}
+	}
+
+	var readErr error
+	if err := viper.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if !errors.As(err, &notFound) {
+			readErr = fmt.Errorf("failed to read config file: %w", err)
 		}
 	}
 
-	// Маппим конфигурацию на структуру Config
 	var config Config
 	if err := viper.Unmarshal(&config); err != nil {
-		return nil, fmt.Errorf("не удалось распарсить конфигурацию: %w", err)
+		return defaultConfig(), errors.Join(readErr, fmt.Errorf("failed to parse config: %w", err))
+	}
+	if readErr == nil {
+		config.File = viper.ConfigFileUsed()
+	}
+	config.sources = make(map[string]Source)
+	for _, key := range Keys() {
+		config.sources[key] = config.findSource(key)
 	}
 
+	if readErr != nil {
+		return &config, readErr
+	}
+	if err := config.Validate(); err != nil {
+		return &config, err
+	}
 	return &config, nil
 }
+
+// defaultConfig возвращает конфигурацию только из значений по умолчанию
+func defaultConfig() *Config {
+	var config Config
+	v := reflect.ValueOf(&config).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if value, ok := defaults[keyOf(v.Type().Field(i))]; ok {
+			v.Field(i).Set(reflect.ValueOf(value))
+		}
+	}
+	return &config
+}
+
+// Keys возвращает имена настроек верхнего уровня в порядке объявления
+func Keys() []string {
+	t := reflect.TypeOf(Config{})
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		if key := keyOf(t.Field(i)); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+func keyOf(field reflect.StructField) string {
+	key := field.Tag.Get("mapstructure")
+	if !field.IsExported() || key == "-" {
+		return ""
+	}
+	return key
+}
+
+func envName(key string) string {
+	return EnvPrefix + "_" + strings.ToUpper(key)
+}
+
+func (c *Config) findSource(key string) Source {
+	if _, ok := defaults[key]; ok {
+		// Пустые переменные viper не учитывает
+		if os.Getenv(envName(key)) != "" {
+			return Source{Kind: SourceEnv, Name: envName(key)}
+		}
+	}
+	if c.File != "" && viper.InConfig(key) {
+		return Source{Kind: SourceFile, Name: c.File}
+	}
+	return Source{Kind: SourceDefault}
+}
+
+// Source возвращает, откуда взято значение настройки key
+func (c *Config) Source(key string) Source {
+	if source, ok := c.sources[key]; ok {
+		return source
+	}
+	return Source{Kind: SourceDefault}
+}
+
+// Validate проверяет значения настроек и возвращает *ValidationError со всеми найденными ошибками
+func (c *Config) Validate() error {
+	var problems []string
+	invalid := func(key, format string, args ...any) {
+		problem := key + ": " + fmt.Sprintf(format, args...)
+		if source := c.Source(key); source.Kind != SourceDefault {
+			problem += " (" + source.String() + ")"
+		}
+		problems = append(problems, problem)
+	}
+
+	if c.DefaultReadingSpeed <= 0 {
+		invalid("default_reading_speed", "must be greater than 0, got %d", c.DefaultReadingSpeed)
+	}
+	if c.DefaultWorkers < 1 {
+		invalid("default_workers", "must be at least 1, got %d", c.DefaultWorkers)
+	}
+	if c.OutputFile == "" {
+		invalid("output_file", "must not be empty")
+	}
+	if _, err := output.ParseFormat(c.OutputFormat); err != nil {
+		invalid("output_format", "%v", err)
+	}
+	if _, err := estimator.ParseSyllableBackend(c.SyllableBackend); err != nil {
+		invalid("syllable_backend", "%v", err)
+	}
+	if _, err := estimator.ParseReadingMode(c.ReadingMode); err != nil {
+		invalid("reading_mode", "%v", err)
+	}
+	if c.CharactersPerMinute < 0 {
+		invalid("characters_per_minute", "must not be negative, got %d", c.CharactersPerMinute)
+	}
+	for language, speed := range c.LanguageSpeeds {
+		if speed <= 0 {
+			invalid("language_speeds", "speed for %s must be greater than 0, got %d", language, speed)
+		}
+	}
+	for _, name := range c.ProfileNames() {
+		if _, err := c.ResolveProfile(name); err != nil {
+			invalid("profiles", "%v", err)
+		}
+	}
+	if c.DefaultProfile != "" {
+		if _, err := c.ResolveProfile(""); errors.Is(err, ErrUnknownProfile) {
+			invalid("default_profile", "%v", err)
+		}
+	}
+	if c.CacheMaxSize < 0 {
+		invalid("cache_max_size", "must not be negative, got %d", c.CacheMaxSize)
+	}
+	if _, err := c.TokenClassPolicies(); err != nil {
+		invalid("token_classes", "%v", err)
+	}
+	if err := c.Check.Rules().Validate(); err != nil {
+		invalid("check", "%v", err)
+	}
+	if _, err := c.Style.Options(); err != nil {
+		invalid("style", "%v", err)
+	}
+	if c.Vocabulary.FrequencyListSize < 0 {
+		invalid("vocabulary", "frequency_list_size must not be negative, got %d", c.Vocabulary.FrequencyListSize)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// TokenClassPolicies дополняет правила по умолчанию для видов токенов правилами из token_classes
+func (c *Config) TokenClassPolicies() (map[estimator.TokenClass]estimator.ClassPolicy, error) {
+	policies := estimator.DefaultClassPolicies()
+	for name, classCfg := range c.TokenClasses {
+		class, err := estimator.ParseTokenClass(name)
+		if err != nil {
+			return nil, err
+		}
+		policy := policies[class]
+		if classCfg.Cost != nil {
+			if *classCfg.Cost < 0 {
+				return nil, fmt.Errorf("cost of %s must not be negative", class)
+			}
+			policy.Cost = *classCfg.Cost
+		}
+		if classCfg.Syllables != "" {
+			if policy.Syllables, err = estimator.ParseSyllablePolicy(classCfg.Syllables); err != nil {
+				return nil, err
+			}
+		}
+		policies[class] = policy
+	}
+	return policies, nil
+}
+
+// WriteEffective записывает действующую конфигурацию в формате YAML;
+// источник каждого значения указывается в комментарии
+func (c *Config) WriteEffective(w io.Writer) error {
+	doc := &yaml.Node{Kind: yaml.MappingNode}
+	v := reflect.ValueOf(c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		key := keyOf(v.Type().Field(i))
+		if key == "" {
+			continue
+		}
+
+		keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: key}
+		valueNode := &yaml.Node{}
+		field := v.Field(i)
+		if field.Kind() == reflect.Map && field.Len() == 0 {
+			valueNode = &yaml.Node{Kind: yaml.MappingNode, Style: yaml.FlowStyle}
+		} else if err := valueNode.Encode(field.Interface()); err != nil {
+			return fmt.Errorf("failed to encode %s: %w", key, err)
+		}
+
+		// У разделов комментарий ставится после ключа, у простых значений — после значения
+		comment := "# " + c.Source(key).String()
+		if valueNode.Kind == yaml.ScalarNode || valueNode.Style == yaml.FlowStyle {
+			valueNode.LineComment = comment
+		} else {
+			keyNode.LineComment = comment
+		}
+		doc.Content = append(doc.Content, keyNode, valueNode)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// DefaultPath возвращает путь к файлу конфигурации пользователя: $XDG_CONFIG_HOME/littime/config.yaml
+func DefaultPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Init создает файл конфигурации со значениями по умолчанию и пояснениями и возвращает путь к нему.
+// Пустой path означает DefaultPath. Существующий файл перезаписывается только при force,
+// иначе возвращается ошибка, совместимая с fs.ErrExist.
+func Init(path string, force bool) (string, error) {
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := os.Stat(path); err == nil && !force {
+		return "", fmt.Errorf("config file %s: %w", path, fs.ErrExist)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, configTemplate, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write config file: %w", err)
+	}
+	return path, nil
+}
+
+// Save записывает значения в файл конфигурации и возвращает путь к нему. Ключи записываются
+// через точку (profiles.anna.reading_speed). Остальное содержимое файла, в том числе комментарии,
+// сохраняется; переменные окружения LITTIME_* и значения по умолчанию в файл не попадают.
+// Если файл не был найден при загрузке, создается файл по пути DefaultPath.
+func Save(values map[string]any) (string, error) {
+	path := viper.ConfigFileUsed()
+	if path == "" {
+		var err error
+		if path, err = DefaultPath(); err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", fmt.Errorf("failed to create config directory: %w", err)
+		}
+	}
+	if err := updateFile(path, values); err != nil {
+		return "", fmt.Errorf("failed to write config file: %w", err)
+	}
+	return path, nil
+}
+
+// updateFile меняет в YAML-файле только ключи из values; несуществующий файл создается
+func updateFile(path string, values map[string]any) error {
+	perm := fs.FileMode(0o644)
+	var doc yaml.Node
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return err
+		}
+		if info, err := os.Stat(path); err == nil {
+			perm = info.Mode().Perm()
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	// В пустом файле или файле из одних комментариев документа нет
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, HeadComment: doc.HeadComment}
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = append(doc.Content, &yaml.Node{Kind: yaml.MappingNode})
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s: top level is not a mapping", path)
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		if err := setNode(root, strings.Split(key, "."), reflect.ValueOf(values[key])); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	return os.WriteFile(path, buf.Bytes(), perm)
+}
+
+// setNode записывает value по пути path в узел mapping, создавая недостающие разделы.
+// Словари записываются по ключам, поэтому ключи файла, которых нет в value, остаются.
+func setNode(mapping *yaml.Node, path []string, value reflect.Value) error {
+	if mapping.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s is not a mapping", path[0])
+	}
+
+	// viper приводит ключи к нижнему регистру, поэтому ключи файла сравниваются без учета регистра
+	var node *yaml.Node
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if strings.EqualFold(mapping.Content[i].Value, path[0]) {
+			node = mapping.Content[i+1]
+			break
+		}
+	}
+	if node == nil {
+		node = &yaml.Node{Kind: yaml.MappingNode}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: path[0]}, node)
+	}
+
+	if len(path) > 1 {
+		return setNode(node, path[1:], value)
+	}
+	if value.Kind() == reflect.Map {
+		if node.Kind != yaml.MappingNode {
+			// Значение другого вида, например пустое, заменяется словарем
+			*node = yaml.Node{Kind: yaml.MappingNode, HeadComment: node.HeadComment, LineComment: node.LineComment}
+		}
+		keys := value.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+		})
+		for _, key := range keys {
+			if err := setNode(node, []string{fmt.Sprint(key.Interface())}, value.MapIndex(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var encoded yaml.Node
+	if err := encoded.Encode(value.Interface()); err != nil {
+		return err
+	}
+	encoded.HeadComment, encoded.LineComment, encoded.FootComment = node.HeadComment, node.LineComment, node.FootComment
+	*node = encoded
+	return nil
+}
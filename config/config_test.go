@@ -0,0 +1,184 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_DIRS", t.TempDir())
+	t.Setenv(PathEnv, "")
+
+	cfg, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig() without a file returned error: %v", err)
+	}
+	if cfg.File != "" || cfg.Source("default_workers").Kind != SourceDefault {
+		t.Errorf("LoadConfig() without a file read %q", cfg.File)
+	}
+	cfg.sources = nil
+	if defaults := defaultConfig(); !reflect.DeepEqual(*cfg, *defaults) {
+		t.Errorf("LoadConfig() without a file = %+v; want %+v", *cfg, *defaults)
+	}
+
+	// Файл из config init содержит те же значения, что и встроенные значения по умолчанию
+	path, err := Init("", false)
+	if err != nil {
+		t.Fatalf("Init() returned error: %v", err)
+	}
+	if want, _ := DefaultPath(); path != want {
+		t.Errorf("Init() path = %s; want %s", path, want)
+	}
+	if _, err := Init("", false); !errors.Is(err, fs.ErrExist) {
+		t.Errorf("Init() over an existing file error = %v; want fs.ErrExist", err)
+	}
+	fromFile, err := LoadConfig("")
+	if err != nil {
+		t.Fatalf("LoadConfig() with the template returned error: %v", err)
+	}
+	if fromFile.File != path {
+		t.Errorf("LoadConfig() found %q; want the file in the XDG directory %q", fromFile.File, path)
+	}
+	for _, key := range Keys() {
+		if _, ok := defaults[key]; ok && fromFile.Source(key).Kind != SourceFile {
+			t.Errorf("Template does not set %s", key)
+		}
+	}
+	fromFile.File, fromFile.sources = "", nil
+	if defaults := defaultConfig(); !reflect.DeepEqual(*fromFile, *defaults) {
+		t.Errorf("Template values = %+v; want %+v", *fromFile, *defaults)
+	}
+
+	// Явный путь и переменные окружения
+	explicit := filepath.Join(t.TempDir(), "littime.yaml")
+	if err := os.WriteFile(explicit, []byte("default_reading_speed: 250\ndefault_workers: 2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LITTIME_DEFAULT_WORKERS", "6")
+	cfg, err = LoadConfig(explicit)
+	if err != nil {
+		t.Fatalf("LoadConfig(%s) returned error: %v", explicit, err)
+	}
+	if cfg.DefaultReadingSpeed != 250 || cfg.DefaultWorkers != 6 {
+		t.Errorf("LoadConfig() speed = %d, workers = %d; want 250 from the file and 6 from the environment",
+			cfg.DefaultReadingSpeed, cfg.DefaultWorkers)
+	}
+	for key, want := range map[string]Source{
+		"default_reading_speed": {Kind: SourceFile, Name: explicit},
+		"default_workers":       {Kind: SourceEnv, Name: "LITTIME_DEFAULT_WORKERS"},
+		"output_format":         {Kind: SourceDefault},
+	} {
+		if got := cfg.Source(key); got != want {
+			t.Errorf("Source(%s) = %v; want %v", key, got, want)
+		}
+	}
+
+	if _, err := LoadConfig(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("LoadConfig() expected an error for a missing explicit file")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cfg := defaultConfig()
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() of the defaults returned error: %v", err)
+	}
+
+	cfg.DefaultReadingSpeed = 0
+	cfg.DefaultWorkers = 0
+	cfg.OutputFormat = "pdf"
+	cfg.ReadingMode = "fast"
+	cfg.LanguageSpeeds = map[string]int{"en": -1}
+	cfg.DefaultProfile = "nobody"
+	cfg.TokenClasses = map[string]TokenClassConfig{"url": {Syllables: "sing"}}
+	cfg.sources = map[string]Source{"default_workers": {Kind: SourceEnv, Name: "LITTIME_DEFAULT_WORKERS"}}
+
+	var validationErr *ValidationError
+	if err := cfg.Validate(); !errors.As(err, &validationErr) {
+		t.Fatalf("Validate() error = %v; want *ValidationError", err)
+	}
+	for _, key := range []string{"default_reading_speed", "default_workers", "output_format", "reading_mode", "language_speeds", "default_profile", "token_classes"} {
+		found := false
+		for _, problem := range validationErr.Problems {
+			found = found || strings.HasPrefix(problem, key+":")
+		}
+		if !found {
+			t.Errorf("Validate() problems %q do not mention %s", validationErr.Problems, key)
+		}
+	}
+	if !strings.Contains(validationErr.Error(), "must be at least 1, got 0 (env LITTIME_DEFAULT_WORKERS)") {
+		t.Errorf("Validate() error %q does not name the source of default_workers", validationErr.Error())
+	}
+}
+
+func TestSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	original := `# Настройки для ноутбука
+default_reading_speed: 180 # до калибровки
+default_workers: 4
+
+language_speeds:
+  de: 150 # немецкий
+profiles:
+  Anna:
+    # Читает медленнее остальных
+    reading_speed: 200
+`
+	if err := os.WriteFile(path, []byte(original), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	// Временные переопределения из окружения не должны попасть в файл
+	t.Setenv(EnvPrefix+"_DEFAULT_WORKERS", "16")
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatalf("LoadConfig() returned error: %v", err)
+	}
+
+	saved, err := Save(map[string]any{
+		"default_reading_speed":                240,
+		"language_speeds":                      map[string]int{"en": 250},
+		"profiles.anna.reading_speed":          260,
+		"profiles.anna.difficulty_sensitivity": 0.3,
+	})
+	if err != nil || saved != path {
+		t.Fatalf("Save() = %s, %v; want %s", saved, err, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	for _, want := range []string{
+		"# Настройки для ноутбука", "default_reading_speed: 240 # до калибровки", "default_workers: 4\n",
+		"de: 150 # немецкий", "en: 250", "Anna:", "# Читает медленнее остальных", "reading_speed: 260", "difficulty_sensitivity: 0.3",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("saved config does not contain %q:\n%s", want, content)
+		}
+	}
+	// Значения по умолчанию, которых не было в файле, не записываются
+	if strings.Contains(content, "output_format") {
+		t.Errorf("saved config contains default values:\n%s", content)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("saved config mode = %v, %v; want 0600", info.Mode().Perm(), err)
+	}
+
+	t.Setenv(EnvPrefix+"_DEFAULT_WORKERS", "")
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() of the saved config returned error: %v", err)
+	}
+	anna, err := cfg.ResolveProfile("anna")
+	if err != nil || cfg.DefaultReadingSpeed != 240 || cfg.LanguageSpeeds["de"] != 150 || cfg.LanguageSpeeds["en"] != 250 ||
+		anna.ReadingSpeed != 260 || anna.DifficultySensitivity != 0.3 {
+		t.Errorf("saved config = %+v, anna = %+v, %v", cfg, anna, err)
+	}
+}
@@ -0,0 +1,173 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"LitTime/estimator"
+)
+
+// ErrUnknownProfile возвращается, если выбранного профиля нет в конфигурации
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// ProfileConfig — профиль читателя в config.yaml. Незаданные поля наследуются от профиля
+// из inherits, а затем берутся из общих настроек (default_reading_speed, language_speeds).
+type ProfileConfig struct {
+	Inherits              string            `mapstructure:"inherits" yaml:"inherits,omitempty"`
+	ReadingSpeed          *int              `mapstructure:"reading_speed" yaml:"reading_speed,omitempty"`
+	LanguageSpeeds        map[string]int    `mapstructure:"language_speeds" yaml:"language_speeds,omitempty"`
+	DifficultySensitivity *float64          `mapstructure:"difficulty_sensitivity" yaml:"difficulty_sensitivity,omitempty"`
+	VisualsFactor         *float64          `mapstructure:"visuals_factor" yaml:"visuals_factor,omitempty"`
+	SkimFactor            *float64          `mapstructure:"skim_factor" yaml:"skim_factor,omitempty"`
+	Proficiency           map[string]string `mapstructure:"proficiency" yaml:"proficiency,omitempty"` // Уровень CEFR по языкам (en, ru)
+}
+
+// Profile — профиль читателя с учетом наследования и значений по умолчанию
+type Profile struct {
+	Name                  string
+	ReadingSpeed          int            // Скорость чтения в словах в минуту
+	LanguageSpeeds        map[string]int // Скорость чтения по языкам (en, ru), слов в минуту
+	DifficultySensitivity float64        // Доля, на которую замедляется чтение сложного текста, от 0 до 1
+	VisualsFactor         float64        // Во сколько раз иллюстрации увеличивают время чтения
+	SkimFactor            float64        // Доля времени чтения при беглом просмотре, от 0 до 1
+
+	// Уровень владения языками (en, ru) от A1 до C2; языки без уровня читаются как родные
+	Proficiency map[string]estimator.CEFRLevel
+}
+
+// ResolveProfile собирает профиль name с учетом цепочки наследования и проверяет его значения.
+// Пустое имя означает профиль из default_profile, а если он не задан — общие настройки.
+// Регистр имени не учитывается: viper приводит ключи конфигурации к нижнему регистру.
+func (c *Config) ResolveProfile(name string) (Profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	name = strings.ToLower(name)
+
+	// Значения, не заданные ни в профиле, ни в его родителях, берутся из профиля оценщика по умолчанию
+	defaults := estimator.DefaultProfile()
+	profile := Profile{
+		Name:                  name,
+		ReadingSpeed:          c.DefaultReadingSpeed,
+		LanguageSpeeds:        make(map[string]int),
+		Proficiency:           make(map[string]estimator.CEFRLevel),
+		DifficultySensitivity: defaults.DifficultySensitivity,
+		VisualsFactor:         defaults.VisualsFactor,
+		SkimFactor:            defaults.SkimFactor,
+	}
+	for language, speed := range c.LanguageSpeeds {
+		profile.LanguageSpeeds[language] = speed
+	}
+	if name == "" {
+		return profile, profile.validate()
+	}
+
+	// Цепочка от выбранного профиля к самому дальнему родителю
+	var chain []ProfileConfig
+	visited := make(map[string]bool)
+	for current := name; current != ""; {
+		if visited[current] {
+			return Profile{}, fmt.Errorf("profile %q: inheritance cycle through %q", name, current)
+		}
+		visited[current] = true
+
+		p, ok := c.Profiles[current]
+		if !ok {
+			if current == name {
+				return Profile{}, fmt.Errorf("%w %q (available: %s)", ErrUnknownProfile, name, strings.Join(c.ProfileNames(), ", "))
+			}
+			return Profile{}, fmt.Errorf("profile %q inherits unknown profile %q", name, current)
+		}
+		chain = append(chain, p)
+		current = strings.ToLower(p.Inherits)
+	}
+
+	// Применяем профили от родителя к потомку, чтобы потомок переопределял значения
+	for i := len(chain) - 1; i >= 0; i-- {
+		p := chain[i]
+		if p.ReadingSpeed != nil {
+			profile.ReadingSpeed = *p.ReadingSpeed
+		}
+		for language, speed := range p.LanguageSpeeds {
+			profile.LanguageSpeeds[language] = speed
+		}
+		for language, value := range p.Proficiency {
+			level, err := estimator.ParseCEFRLevel(value)
+			if err != nil {
+				return Profile{}, fmt.Errorf("profile %q: proficiency.%s: %w", name, language, err)
+			}
+			profile.Proficiency[language] = level
+		}
+		if p.DifficultySensitivity != nil {
+			profile.DifficultySensitivity = *p.DifficultySensitivity
+		}
+		if p.VisualsFactor != nil {
+			profile.VisualsFactor = *p.VisualsFactor
+		}
+		if p.SkimFactor != nil {
+			profile.SkimFactor = *p.SkimFactor
+		}
+	}
+
+	if err := profile.validate(); err != nil {
+		return Profile{}, fmt.Errorf("profile %q: %w", name, err)
+	}
+	return profile, nil
+}
+
+// ProfileNames возвращает имена профилей в алфавитном порядке
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (p Profile) validate() error {
+	if p.ReadingSpeed <= 0 {
+		return fmt.Errorf("reading_speed must be positive, got %d", p.ReadingSpeed)
+	}
+	for language, speed := range p.LanguageSpeeds {
+		if speed <= 0 {
+			return fmt.Errorf("language_speeds.%s must be positive, got %d", language, speed)
+		}
+	}
+	if p.DifficultySensitivity < 0 || p.DifficultySensitivity >= 1 {
+		return fmt.Errorf("difficulty_sensitivity must be in [0, 1), got %g", p.DifficultySensitivity)
+	}
+	if p.VisualsFactor < 1 {
+		return fmt.Errorf("visuals_factor must be at least 1, got %g", p.VisualsFactor)
+	}
+	if p.SkimFactor <= 0 || p.SkimFactor > 1 {
+		return fmt.Errorf("skim_factor must be in (0, 1], got %g", p.SkimFactor)
+	}
+	return nil
+}
+
+// WithReadingSpeed возвращает профиль, в котором все языки читаются со скоростью speed.
+// Так применяется скорость, заданная явно (флагом --speed): иначе скорости по языкам,
+// например сохраненные командой calibrate, перекрыли бы ее для английского и русского.
+func (p Profile) WithReadingSpeed(speed int) Profile {
+	p.ReadingSpeed = speed
+	p.LanguageSpeeds = nil
+	return p
+}
+
+// Estimator возвращает профиль в виде, который принимает оценщик
+func (p Profile) Estimator() *estimator.Profile {
+	speeds := make(map[string]float64, len(p.LanguageSpeeds))
+	for language, speed := range p.LanguageSpeeds {
+		speeds[language] = float64(speed)
+	}
+	return &estimator.Profile{
+		LanguageSpeeds:        speeds,
+		DifficultySensitivity: p.DifficultySensitivity,
+		VisualsFactor:         p.VisualsFactor,
+		SkimFactor:            p.SkimFactor,
+		Proficiency:           p.Proficiency,
+	}
+}
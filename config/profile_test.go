@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"LitTime/estimator"
+)
+
+func intPtr(v int) *int           { return &v }
+func floatPtr(v float64) *float64 { return &v }
+
+func TestResolveProfile(t *testing.T) {
+	cfg := &Config{
+		DefaultReadingSpeed: 180,
+		LanguageSpeeds:      map[string]int{"en": 200},
+		Profiles: map[string]ProfileConfig{
+			"team":   {ReadingSpeed: intPtr(220), LanguageSpeeds: map[string]int{"ru": 240}, SkimFactor: floatPtr(0.4), Proficiency: map[string]string{"en": "b1"}},
+			"anna":   {Inherits: "team", LanguageSpeeds: map[string]int{"en": 260}, DifficultySensitivity: floatPtr(0.1)},
+			"loop1":  {Inherits: "loop2"},
+			"loop2":  {Inherits: "loop1"},
+			"slow":   {Inherits: "team", ReadingSpeed: intPtr(0)},
+			"orph":   {Inherits: "missing"},
+			"fluent": {Proficiency: map[string]string{"en": "D1"}},
+		},
+	}
+
+	base, err := cfg.ResolveProfile("")
+	if err != nil || base.ReadingSpeed != 180 || base.LanguageSpeeds["en"] != 200 || base.VisualsFactor != 1.1 {
+		t.Errorf("ResolveProfile(\"\") = %+v, %v; want top-level settings", base, err)
+	}
+
+	anna, err := cfg.ResolveProfile("Anna")
+	if err != nil {
+		t.Fatalf("ResolveProfile(Anna) returned error: %v", err)
+	}
+	if anna.ReadingSpeed != 220 || anna.LanguageSpeeds["en"] != 260 || anna.LanguageSpeeds["ru"] != 240 ||
+		anna.SkimFactor != 0.4 || anna.DifficultySensitivity != 0.1 || anna.Proficiency["en"] != estimator.CEFRB1 {
+		t.Errorf("ResolveProfile(Anna) = %+v; want values inherited from team and overridden by anna", anna)
+	}
+
+	if _, err := cfg.ResolveProfile("bob"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("ResolveProfile(bob) error = %v; want ErrUnknownProfile", err)
+	}
+	for name, message := range map[string]string{"loop1": "cycle", "slow": "reading_speed", "orph": "missing", "fluent": "proficiency"} {
+		if _, err := cfg.ResolveProfile(name); err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("ResolveProfile(%s) error = %v; want it to mention %q", name, err, message)
+		}
+	}
+
+	cfg.DefaultProfile = "team"
+	if team, err := cfg.ResolveProfile(""); err != nil || team.ReadingSpeed != 220 {
+		t.Errorf("ResolveProfile(\"\") with default_profile = %+v, %v; want team", team, err)
+	}
+}
+
+func TestWithReadingSpeed(t *testing.T) {
+	cfg := &Config{DefaultReadingSpeed: 180, LanguageSpeeds: map[string]int{"en": 300}}
+	profile, err := cfg.ResolveProfile("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Явная скорость должна действовать и на слова языков, для которых в профиле есть своя скорость
+	text := strings.Repeat("The quick brown fox jumps over the lazy dog. ", 20)
+	estimate := func(speed int) float64 {
+		p := profile.WithReadingSpeed(speed)
+		result, err := estimator.Estimate(text, estimator.Options{ReadingSpeed: float64(p.ReadingSpeed), Workers: 1, Profile: p.Estimator()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.ReadingTime
+	}
+	slow, fast := estimate(100), estimate(1000)
+	if slow <= fast {
+		t.Errorf("reading time at 100 wpm = %.2f, at 1000 wpm = %.2f; want --speed to override language_speeds", slow, fast)
+	}
+	if profile.LanguageSpeeds["en"] != 300 {
+		t.Errorf("WithReadingSpeed changed the original profile: %v", profile.LanguageSpeeds)
+	}
+}
@@ -0,0 +1,226 @@
+package diff
+
+import (
+	"math"
+	"strings"
+
+	"LitTime/estimator"
+)
+
+// Параметры сопоставления абзацев
+const (
+	// MinSimilarity — наименьшая доля общих слов, при которой абзацы считаются версиями друг друга
+	MinSimilarity = 0.5
+	// DefaultThreshold — изменение индекса Флеша-Кинкейда, начиная с которого абзац стал проще или сложнее
+	DefaultThreshold = 5.0
+)
+
+// Status описывает, что произошло с абзацем
+type Status string
+
+const (
+	StatusUnchanged Status = "unchanged"
+	StatusModified  Status = "modified"
+	StatusAdded     Status = "added"
+	StatusRemoved   Status = "removed"
+)
+
+// Trend показывает, как изменилась сложность измененного абзаца
+type Trend string
+
+const (
+	TrendHarder Trend = "harder"
+	TrendEasier Trend = "easier"
+	TrendSame   Trend = "same"
+)
+
+// Metric — показатель документа в двух версиях
+type Metric struct {
+	Name   string  `json:"name" yaml:"name"`
+	Old    float64 `json:"old" yaml:"old"`
+	New    float64 `json:"new" yaml:"new"`
+	Change float64 `json:"change" yaml:"change"`
+	Better string  `json:"better,omitempty" yaml:"better,omitempty"` // "higher" или "lower", если у показателя есть лучшее направление
+}
+
+// ParagraphChange — сопоставленная пара абзацев; у добавленного нет Old, у удаленного — New
+type ParagraphChange struct {
+	Status     Status                  `json:"status" yaml:"status"`
+	Trend      Trend                   `json:"trend,omitempty" yaml:"trend,omitempty"`
+	Similarity float64                 `json:"similarity" yaml:"similarity"`
+	Old        *estimator.PassageStats `json:"old,omitempty" yaml:"old,omitempty"`
+	New        *estimator.PassageStats `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// Summary — количество абзацев по видам изменений
+type Summary struct {
+	Unchanged int `json:"unchanged" yaml:"unchanged"`
+	Harder    int `json:"harder" yaml:"harder"`
+	Easier    int `json:"easier" yaml:"easier"`
+	Modified  int `json:"modified" yaml:"modified"` // Измененные абзацы, сложность которых почти не изменилась
+	Added     int `json:"added" yaml:"added"`
+	Removed   int `json:"removed" yaml:"removed"`
+}
+
+// Comparison — результат сравнения двух версий документа
+type Comparison struct {
+	Metrics    []Metric          `json:"metrics" yaml:"metrics"`
+	Summary    Summary           `json:"summary" yaml:"summary"`
+	Paragraphs []ParagraphChange `json:"paragraphs" yaml:"paragraphs"`
+}
+
+// Compare сравнивает две версии документа. Результаты должны содержать показатели абзацев
+// (Options.Detailed). Абзац считается проще или сложнее, если его индекс читаемости изменился
+// не меньше чем на threshold.
+func Compare(old, new *estimator.Result, threshold float64) Comparison {
+	comparison := Comparison{
+		Metrics:    metrics(old, new),
+		Paragraphs: Align(old.Paragraphs, new.Paragraphs, threshold),
+	}
+	for _, p := range comparison.Paragraphs {
+		switch {
+		case p.Status == StatusUnchanged:
+			comparison.Summary.Unchanged++
+		case p.Status == StatusAdded:
+			comparison.Summary.Added++
+		case p.Status == StatusRemoved:
+			comparison.Summary.Removed++
+		case p.Trend == TrendHarder:
+			comparison.Summary.Harder++
+		case p.Trend == TrendEasier:
+			comparison.Summary.Easier++
+		default:
+			comparison.Summary.Modified++
+		}
+	}
+	return comparison
+}
+
+func metrics(old, new *estimator.Result) []Metric {
+	metric := func(name string, oldValue, newValue float64, better string) Metric {
+		return Metric{Name: name, Old: oldValue, New: newValue, Change: round(newValue - oldValue), Better: better}
+	}
+	metrics := []Metric{
+		metric("Reading time", old.ReadingTime, new.ReadingTime, ""),
+		metric("Words", float64(old.WordCount), float64(new.WordCount), ""),
+		metric("Sentences", float64(old.SentenceCount), float64(new.SentenceCount), ""),
+		metric("Syllables", float64(old.SyllableCount), float64(new.SyllableCount), ""),
+	}
+	// Индекс сравнивается, только если он применим к обеим версиям
+	if !old.FleschKincaidNotApplicable && !new.FleschKincaidNotApplicable {
+		metrics = append(metrics, metric("Flesch-Kincaid Index", round(old.FleschKincaidIndex), round(new.FleschKincaidIndex), "higher"))
+	}
+	return append(metrics,
+		metric("Words per sentence", round(ratio(old.WordCount, old.SentenceCount)), round(ratio(new.WordCount, new.SentenceCount)), "lower"),
+		metric("Syllables per word", round(ratio(old.SyllableCount, old.WordCount)), round(ratio(new.SyllableCount, new.WordCount)), "lower"),
+	)
+}
+
+// Align сопоставляет абзацы двух версий с сохранением порядка так, чтобы сумма сходства пар была наибольшей.
+// Пары со сходством меньше MinSimilarity не образуются: такие абзацы считаются удаленными и добавленными.
+func Align(old, new []estimator.PassageStats, threshold float64) []ParagraphChange {
+	oldWords := make([]map[string]int, len(old))
+	for i, p := range old {
+		oldWords[i] = wordCounts(p.Text)
+	}
+	newWords := make([]map[string]int, len(new))
+	for j, p := range new {
+		newWords[j] = wordCounts(p.Text)
+	}
+
+	similarity := make([][]float64, len(old))
+	for i := range old {
+		similarity[i] = make([]float64, len(new))
+		for j := range new {
+			similarity[i][j] = jaccard(oldWords[i], newWords[j])
+		}
+	}
+
+	// score[i][j] — наибольшая сумма сходства для old[i:] и new[j:]
+	score := make([][]float64, len(old)+1)
+	for i := range score {
+		score[i] = make([]float64, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			best := max(score[i+1][j], score[i][j+1])
+			if s := similarity[i][j]; s >= MinSimilarity {
+				best = max(best, score[i+1][j+1]+s)
+			}
+			score[i][j] = best
+		}
+	}
+
+	var changes []ParagraphChange
+	i, j := 0, 0
+	for i < len(old) || j < len(new) {
+		switch {
+		case i < len(old) && j < len(new) && similarity[i][j] >= MinSimilarity && score[i][j] == score[i+1][j+1]+similarity[i][j]:
+			changes = append(changes, pair(&old[i], &new[j], similarity[i][j], threshold))
+			i++
+			j++
+		case i < len(old) && (j == len(new) || score[i][j] == score[i+1][j]):
+			changes = append(changes, ParagraphChange{Status: StatusRemoved, Old: &old[i]})
+			i++
+		default:
+			changes = append(changes, ParagraphChange{Status: StatusAdded, New: &new[j]})
+			j++
+		}
+	}
+	return changes
+}
+
+func pair(old, new *estimator.PassageStats, similarity, threshold float64) ParagraphChange {
+	change := ParagraphChange{Status: StatusModified, Trend: TrendSame, Similarity: round(similarity), Old: old, New: new}
+	if normalize(old.Text) == normalize(new.Text) {
+		change.Status, change.Trend = StatusUnchanged, ""
+		return change
+	}
+	switch delta := new.FleschKincaidIndex - old.FleschKincaidIndex; {
+	case delta <= -threshold:
+		change.Trend = TrendHarder
+	case delta >= threshold:
+		change.Trend = TrendEasier
+	}
+	return change
+}
+
+// wordCounts возвращает количество каждого слова без учета регистра
+func wordCounts(text string) map[string]int {
+	_, words := estimator.CountWords(text)
+	counts := make(map[string]int, len(words))
+	for _, word := range words {
+		counts[strings.ToLower(word)]++
+	}
+	return counts
+}
+
+// jaccard возвращает долю общих слов двух абзацев с учетом повторов
+func jaccard(a, b map[string]int) float64 {
+	var common, total int
+	for word, n := range a {
+		common += min(n, b[word])
+		total += max(n, b[word])
+	}
+	for word, n := range b {
+		if _, ok := a[word]; !ok {
+			total += n
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(common) / float64(total)
+}
+
+func normalize(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+func ratio(a, b int) float64 {
+	return float64(a) / math.Max(float64(b), 1)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,92 @@
+package diff
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"LitTime/estimator"
+	"LitTime/output"
+)
+
+func estimate(t *testing.T, text string) *estimator.Result {
+	t.Helper()
+	result, err := estimator.Estimate(text, estimator.Options{ReadingSpeed: 200, Workers: 2, Detailed: true})
+	if err != nil {
+		t.Fatalf("Estimate() returned error: %v", err)
+	}
+	return &result
+}
+
+func TestCompare(t *testing.T) {
+	oldText := `The cat sat on the mat. It was warm and happy.
+
+This paragraph will be removed from the next version of the text entirely.
+
+We went to the shop. We bought some bread and milk. Then we went home.
+
+The report is short. It is easy to read.`
+
+	newText := `The cat sat on the mat. It was warm and happy.
+
+We went to the shop. We bought some bread, milk and notwithstanding considerable institutional deliberation, extraordinarily complicated administrative paperwork. Then we went home.
+
+A brand new paragraph appears here with several fresh words.
+
+The report is short. It is easy to read!`
+
+	c := Compare(estimate(t, oldText), estimate(t, newText), DefaultThreshold)
+
+	want := []struct {
+		status Status
+		trend  Trend
+	}{
+		{StatusUnchanged, ""},
+		{StatusRemoved, ""},
+		{StatusModified, TrendHarder},
+		{StatusAdded, ""},
+		{StatusModified, TrendSame},
+	}
+	if len(c.Paragraphs) != len(want) {
+		t.Fatalf("Compare() returned %d paragraph changes; want %d: %+v", len(c.Paragraphs), len(want), c.Paragraphs)
+	}
+	for i, w := range want {
+		if p := c.Paragraphs[i]; p.Status != w.status || p.Trend != w.trend {
+			t.Errorf("Paragraph change %d = %s/%s; want %s/%s", i, p.Status, p.Trend, w.status, w.trend)
+		}
+	}
+	if c.Summary != (Summary{Unchanged: 1, Harder: 1, Modified: 1, Added: 1, Removed: 1}) {
+		t.Errorf("Summary = %+v", c.Summary)
+	}
+	if words := c.Metrics[1]; words.Name != "Words" || words.Change <= 0 {
+		t.Errorf("Words metric = %+v; want a positive change", words)
+	}
+
+	var text bytes.Buffer
+	if err := Write(&text, c, output.FormatText); err != nil {
+		t.Fatalf("Write(text) returned error: %v", err)
+	}
+	for _, s := range []string{"Flesch-Kincaid Index", "¶3 → ¶2  harder", "— → ¶3", "¶2 → —"} {
+		if !strings.Contains(text.String(), s) {
+			t.Errorf("Write(text) does not contain %q:\n%s", s, text.String())
+		}
+	}
+}
+
+func TestAlignReordered(t *testing.T) {
+	paragraph := func(n int, text string) estimator.PassageStats {
+		return estimator.PassageStats{Paragraph: n, Text: text}
+	}
+	old := []estimator.PassageStats{paragraph(1, "alpha beta gamma"), paragraph(2, "delta epsilon zeta")}
+	new := []estimator.PassageStats{paragraph(1, "delta epsilon zeta"), paragraph(2, "alpha beta gamma")}
+
+	// Абзацы, поменявшиеся местами, не могут оба сохранить порядок: один удален и добавлен заново
+	changes := Align(old, new, DefaultThreshold)
+	statuses := make([]Status, len(changes))
+	for i, c := range changes {
+		statuses[i] = c.Status
+	}
+	if len(changes) != 3 || statuses[1] != StatusUnchanged {
+		t.Errorf("Align() of swapped paragraphs = %v; want one unchanged pair, one removal and one addition", statuses)
+	}
+}
@@ -0,0 +1,156 @@
+package diff
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+
+	"LitTime/output"
+)
+
+// Длина начала абзаца, которое выводится в таблице изменений
+const previewLength = 60
+
+// Formats возвращает форматы, в которых можно вывести сравнение
+func Formats() []output.Format {
+	return []output.Format{output.FormatText, output.FormatMarkdown, output.FormatJSON, output.FormatYAML}
+}
+
+// Write записывает сравнение в w в одном из форматов Formats
+func Write(w io.Writer, c Comparison, format output.Format) error {
+	switch format {
+	case output.FormatText:
+		return writeText(w, c)
+	case output.FormatMarkdown:
+		return writeMarkdown(w, c)
+	case output.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(c)
+	case output.FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(c); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("comparison cannot be written as %s", format)
+	}
+}
+
+// verdict сообщает, стал ли показатель лучше или хуже
+func (m Metric) verdict() string {
+	if m.Change == 0 || m.Better == "" {
+		return ""
+	}
+	if (m.Change > 0) == (m.Better == "higher") {
+		return "better"
+	}
+	return "worse"
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("%d harder, %d easier, %d modified, %d added, %d removed, %d unchanged",
+		s.Harder, s.Easier, s.Modified, s.Added, s.Removed, s.Unchanged)
+}
+
+// label возвращает вид изменения абзаца: harder, easier, modified, added или removed
+func (p ParagraphChange) label() string {
+	if p.Status == StatusModified && p.Trend != TrendSame {
+		return string(p.Trend)
+	}
+	return string(p.Status)
+}
+
+// numbers возвращает номера абзацев в старой и новой версии, "—" — если абзаца нет
+func (p ParagraphChange) numbers() (string, string) {
+	oldNumber, newNumber := "—", "—"
+	if p.Old != nil {
+		oldNumber = fmt.Sprintf("¶%d", p.Old.Paragraph)
+	}
+	if p.New != nil {
+		newNumber = fmt.Sprintf("¶%d", p.New.Paragraph)
+	}
+	return oldNumber, newNumber
+}
+
+// index возвращает индекс читаемости абзаца в обеих версиях и его изменение
+func (p ParagraphChange) index() string {
+	switch {
+	case p.Old == nil:
+		return fmt.Sprintf("%.1f", p.New.FleschKincaidIndex)
+	case p.New == nil:
+		return fmt.Sprintf("%.1f", p.Old.FleschKincaidIndex)
+	default:
+		return fmt.Sprintf("%.1f → %.1f (%+.1f)", p.Old.FleschKincaidIndex, p.New.FleschKincaidIndex,
+			p.New.FleschKincaidIndex-p.Old.FleschKincaidIndex)
+	}
+}
+
+func (p ParagraphChange) preview() string {
+	text := p.Old
+	if p.New != nil {
+		text = p.New
+	}
+	preview := strings.Join(strings.Fields(text.Text), " ")
+	if utf8.RuneCountInString(preview) > previewLength {
+		preview = string([]rune(preview)[:previewLength]) + "…"
+	}
+	return preview
+}
+
+func writeText(w io.Writer, c Comparison) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METRIC\tOLD\tNEW\tCHANGE\t")
+	for _, m := range c.Metrics {
+		fmt.Fprintf(tw, "%s\t%g\t%g\t%+g\t%s\n", m.Name, m.Old, m.New, m.Change, m.verdict())
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nParagraphs: %s\n", c.Summary)
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, p := range c.Paragraphs {
+		if p.Status == StatusUnchanged {
+			continue
+		}
+		oldNumber, newNumber := p.numbers()
+		fmt.Fprintf(tw, "%s → %s\t%s\tFK %s\t%s\n", oldNumber, newNumber, p.label(), p.index(), p.preview())
+	}
+	return tw.Flush()
+}
+
+func writeMarkdown(w io.Writer, c Comparison) error {
+	var b strings.Builder
+	b.WriteString("| Metric | Old | New | Change | |\n")
+	b.WriteString("| --- | ---: | ---: | ---: | --- |\n")
+	for _, m := range c.Metrics {
+		fmt.Fprintf(&b, "| %s | %g | %g | %+g | %s |\n", m.Name, m.Old, m.New, m.Change, m.verdict())
+	}
+
+	fmt.Fprintf(&b, "\n**Paragraphs:** %s\n", c.Summary)
+	changed := false
+	for _, p := range c.Paragraphs {
+		if p.Status == StatusUnchanged {
+			continue
+		}
+		if !changed {
+			b.WriteString("\n| Paragraph | Change | Flesch-Kincaid | Text |\n")
+			b.WriteString("| --- | --- | --- | --- |\n")
+			changed = true
+		}
+		oldNumber, newNumber := p.numbers()
+		preview := strings.ReplaceAll(p.preview(), "|", `\|`)
+		fmt.Fprintf(&b, "| %s → %s | %s | %s | %s |\n", oldNumber, newNumber, p.label(), p.index(), preview)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,187 @@
+package estimator
+
+import (
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// CEFRLevel — уровень владения языком по шкале CEFR
+type CEFRLevel string
+
+const (
+	CEFRA1 CEFRLevel = "A1"
+	CEFRA2 CEFRLevel = "A2"
+	CEFRB1 CEFRLevel = "B1"
+	CEFRB2 CEFRLevel = "B2"
+	CEFRC1 CEFRLevel = "C1"
+	CEFRC2 CEFRLevel = "C2"
+)
+
+// CEFRLevels возвращает уровни CEFR от простого к сложному
+func CEFRLevels() []CEFRLevel {
+	return []CEFRLevel{CEFRA1, CEFRA2, CEFRB1, CEFRB2, CEFRC1, CEFRC2}
+}
+
+// ParseCEFRLevel разбирает название уровня CEFR без учета регистра
+func ParseCEFRLevel(name string) (CEFRLevel, error) {
+	level := CEFRLevel(strings.ToUpper(strings.TrimSpace(name)))
+	if !slices.Contains(CEFRLevels(), level) {
+		return "", fmt.Errorf("%w %q (supported: A1, A2, B1, B2, C1, C2)", ErrUnknownCEFRLevel, name)
+	}
+	return level, nil
+}
+
+// Rank возвращает номер уровня: 0 для A1, 5 для C2
+func (l CEFRLevel) Rank() int {
+	return slices.Index(CEFRLevels(), l)
+}
+
+// CEFREstimate — примерный уровень CEFR текста на одном языке и признаки, по которым он определен
+type CEFREstimate struct {
+	Language string    `json:"language" yaml:"language" toml:"language"`
+	Level    CEFRLevel `json:"level" yaml:"level" toml:"level"`
+	Score    float64   `json:"score" yaml:"score" toml:"score"` // Сложность от 0 (простой текст A1) до 1 (C2)
+	Words    int       `json:"words" yaml:"words" toml:"words"`
+
+	OutsideCoreVocabulary float64 `json:"outside_core_vocabulary" yaml:"outside_core_vocabulary" toml:"outside_core_vocabulary"` // Доля слов вне базового словаря в процентах
+	AverageSentenceWords  float64 `json:"average_sentence_words" yaml:"average_sentence_words" toml:"average_sentence_words"`
+	AverageSyllables      float64 `json:"average_syllables" yaml:"average_syllables" toml:"average_syllables"` // Слогов на слово
+}
+
+// cefrModel задает для языка значения признаков, которые соответствуют самому простому (A1)
+// и самому сложному (C2) тексту; промежуточные значения переводятся в сложность линейно
+type cefrModel struct {
+	outside       [2]float64 // Доля слов вне базового словаря
+	sentenceWords [2]float64 // Слов в предложении
+	syllables     [2]float64 // Слогов на слово
+}
+
+// Вклад признаков в сложность: словарь важнее для изучающих язык, чем синтаксис и длина слов
+const (
+	cefrVocabularyWeight = 0.5
+	cefrSentenceWeight   = 0.3
+	cefrSyllableWeight   = 0.2
+)
+
+var (
+	// В русском слова длиннее, а формы слов разнообразнее, поэтому границы выше
+	cefrModels = map[string]cefrModel{
+		LanguageEnglish: {outside: [2]float64{0.1, 0.5}, sentenceWords: [2]float64{7, 28}, syllables: [2]float64{1.2, 1.8}},
+		LanguageRussian: {outside: [2]float64{0.2, 0.6}, sentenceWords: [2]float64{5, 20}, syllables: [2]float64{1.9, 3}},
+	}
+
+	//go:embed data/core-en.txt
+	englishCoreVocabulary string
+	//go:embed data/core-ru.txt
+	russianCoreVocabulary string
+
+	coreVocabularyOnce sync.Once
+	coreVocabulary     map[string]map[string]bool // Основы слов базового словаря по языкам
+)
+
+func loadCoreVocabulary() {
+	coreVocabulary = make(map[string]map[string]bool)
+	for language, list := range map[string]string{LanguageEnglish: englishCoreVocabulary, LanguageRussian: russianCoreVocabulary} {
+		// Встроенные списки читаются из строки, ошибок чтения не бывает
+		words, _ := ReadFrequencyList(strings.NewReader(list), 0)
+		coreVocabulary[language] = frequencySet(words, language)
+	}
+}
+
+// cefrFeatures — признаки сложности текста на одном языке
+type cefrFeatures struct {
+	words         int // Слова языка
+	outside       int // Слова вне базового словаря
+	syllables     int
+	sentences     int // Предложения, в которых слов этого языка больше всего
+	sentenceWords int // Все слова таких предложений
+}
+
+// EstimateCEFR определяет примерный уровень CEFR текста для каждого языка, для которого есть
+// базовый словарь (английский и русский). Учитываются доля слов вне базового словаря, длина
+// предложений и слов. Языки упорядочены по числу слов.
+func EstimateCEFR(text string, backend SyllableBackend) []CEFREstimate {
+	coreVocabularyOnce.Do(loadCoreVocabulary)
+
+	features := make(map[string]*cefrFeatures)
+	for _, sentence := range SplitSentences(text) {
+		words := make(map[string]int)
+		total := 0
+		for _, token := range Tokenize(sentence.Text) {
+			if token.Class != TokenWord {
+				continue
+			}
+			total++
+			language := wordLanguage(token.Text)
+			if _, ok := cefrModels[language]; !ok {
+				continue
+			}
+			words[language]++
+			f := features[language]
+			if f == nil {
+				f = &cefrFeatures{}
+				features[language] = f
+			}
+			f.words++
+			f.syllables += CountSyllablesWith(token.Text, backend)
+			if !coreVocabulary[language][StemLanguage(token.Text, language)] {
+				f.outside++
+			}
+		}
+
+		// Предложение относится к языку большинства слов; при равенстве — к первому по алфавиту
+		main := ""
+		for language, count := range words {
+			if main == "" || count > words[main] || (count == words[main] && language < main) {
+				main = language
+			}
+		}
+		if main != "" {
+			features[main].sentences++
+			features[main].sentenceWords += total
+		}
+	}
+
+	var estimates []CEFREstimate
+	for language, f := range features {
+		model := cefrModels[language]
+		estimate := CEFREstimate{
+			Language:              language,
+			Words:                 f.words,
+			OutsideCoreVocabulary: 100 * float64(f.outside) / float64(f.words),
+			AverageSyllables:      float64(f.syllables) / float64(f.words),
+		}
+		sentenceScore := 0.0
+		if f.sentences > 0 {
+			estimate.AverageSentenceWords = float64(f.sentenceWords) / float64(f.sentences)
+			sentenceScore = scale(estimate.AverageSentenceWords, model.sentenceWords)
+		}
+		estimate.Score = cefrVocabularyWeight*scale(float64(f.outside)/float64(f.words), model.outside) +
+			cefrSentenceWeight*sentenceScore +
+			cefrSyllableWeight*scale(estimate.AverageSyllables, model.syllables)
+		estimate.Level = cefrLevel(estimate.Score)
+		estimates = append(estimates, estimate)
+	}
+
+	slices.SortFunc(estimates, func(a, b CEFREstimate) int {
+		if a.Words != b.Words {
+			return b.Words - a.Words
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	return estimates
+}
+
+// scale переводит значение из диапазона bounds в [0, 1]
+func scale(value float64, bounds [2]float64) float64 {
+	return min(max((value-bounds[0])/(bounds[1]-bounds[0]), 0), 1)
+}
+
+// cefrLevel делит шкалу сложности на шесть равных частей
+func cefrLevel(score float64) CEFRLevel {
+	levels := CEFRLevels()
+	return levels[min(int(score*float64(len(levels))), len(levels)-1)]
+}
@@ -0,0 +1,69 @@
+package estimator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEstimateCEFR(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want CEFRLevel
+	}{
+		{
+			name: "english beginner",
+			text: "My name is Tom. I am ten years old. I have a dog. His name is Max. He is big and brown. Every morning we walk in the park.",
+			want: CEFRA1,
+		},
+		{
+			name: "english academic",
+			text: "The epistemological implications of this paradigm shift are considerable, insofar as they undermine the presupposition " +
+				"that empirical observation can be disentangled from the theoretical commitments of the observer.",
+			want: CEFRC2,
+		},
+		{
+			name: "russian beginner",
+			text: "Меня зовут Анна. Я живу в Москве. У меня есть кошка. Утром я пью чай и ем хлеб. Потом я иду в школу.",
+			want: CEFRA1,
+		},
+		{
+			name: "russian academic",
+			text: "Эпистемологические последствия подобной смены парадигмы весьма значительны, поскольку они подрывают представление " +
+				"о том, что эмпирическое наблюдение может быть отделено от теоретических установок наблюдателя.",
+			want: CEFRC2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimates := EstimateCEFR(test.text, SyllablesHeuristic)
+			if len(estimates) != 1 {
+				t.Fatalf("got %d estimates, want 1: %+v", len(estimates), estimates)
+			}
+			if got := estimates[0]; got.Level != test.want {
+				t.Errorf("level = %s (score %.2f), want %s: %+v", got.Level, got.Score, test.want, got)
+			}
+		})
+	}
+}
+
+func TestEstimateCEFRLanguages(t *testing.T) {
+	text := "Это простой текст. Мы читаем книгу. The cat is here."
+	estimates := EstimateCEFR(text, SyllablesHeuristic)
+	if len(estimates) != 2 || estimates[0].Language != LanguageRussian || estimates[1].Language != LanguageEnglish {
+		t.Fatalf("estimates = %+v, want ru and en", estimates)
+	}
+	if estimates[0].AverageSentenceWords != 3 || estimates[1].AverageSentenceWords != 4 {
+		t.Errorf("average sentence words = %g and %g, want 3 and 4", estimates[0].AverageSentenceWords, estimates[1].AverageSentenceWords)
+	}
+}
+
+func TestParseCEFRLevel(t *testing.T) {
+	if level, err := ParseCEFRLevel(" b2 "); err != nil || level != CEFRB2 || level.Rank() != 3 {
+		t.Errorf("ParseCEFRLevel(\" b2 \") = %q, %v, want B2", level, err)
+	}
+	if _, err := ParseCEFRLevel("D1"); !errors.Is(err, ErrUnknownCEFRLevel) {
+		t.Errorf("ParseCEFRLevel(\"D1\") error = %v, want ErrUnknownCEFRLevel", err)
+	}
+}
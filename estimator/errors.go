@@ -0,0 +1,91 @@
+package estimator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Ошибки оценки текста. Функции пакета оборачивают их пояснениями, поэтому проверять их нужно через errors.Is.
+var (
+	ErrEmptyText              = errors.New("text is empty or invalid")
+	ErrInvalidSpeed           = errors.New("invalid reading speed")
+	ErrInvalidWorkers         = errors.New("invalid number of workers")
+	ErrInvalidHardest         = errors.New("invalid number of hardest sentences")
+	ErrInvalidProfile         = errors.New("invalid reader profile")
+	ErrInvalidClassPolicy     = errors.New("invalid token class policy")
+	ErrUnknownReadingMode     = errors.New("unknown reading mode")
+	ErrUnknownSyllableBackend = errors.New("unknown syllable backend")
+	ErrUnknownTokenClass      = errors.New("unknown token class")
+	ErrUnknownSyllablePolicy  = errors.New("unknown syllable policy")
+	ErrUnknownCEFRLevel       = errors.New("unknown CEFR level")
+)
+
+// Validate проверяет параметры оценки. Ошибка оборачивает одну из ошибок пакета (ErrInvalidSpeed и т.д.).
+func (opts Options) Validate() error {
+	if err := opts.validateReading(); err != nil {
+		return err
+	}
+	if opts.Workers < 1 {
+		return fmt.Errorf("%w: must be at least 1, got %d", ErrInvalidWorkers, opts.Workers)
+	}
+	if opts.Hardest < 0 {
+		return fmt.Errorf("%w: must not be negative, got %d", ErrInvalidHardest, opts.Hardest)
+	}
+	return nil
+}
+
+// validateReading проверяет параметры, от которых зависит время чтения фрагмента
+func (opts Options) validateReading() error {
+	if !isPositive(opts.ReadingSpeed) {
+		return fmt.Errorf("%w: must be greater than 0, got %g", ErrInvalidSpeed, opts.ReadingSpeed)
+	}
+	if opts.CharactersPerMinute != 0 && !isPositive(opts.CharactersPerMinute) {
+		return fmt.Errorf("%w: characters per minute must be greater than 0, got %g", ErrInvalidSpeed, opts.CharactersPerMinute)
+	}
+	if _, err := ParseSyllableBackend(string(opts.Syllables)); err != nil {
+		return err
+	}
+	if _, err := ParseReadingMode(string(opts.Mode)); err != nil {
+		return err
+	}
+
+	if p := opts.Profile; p != nil {
+		for language, speed := range p.LanguageSpeeds {
+			if !isPositive(speed) {
+				return fmt.Errorf("%w: speed for %s must be greater than 0, got %g", ErrInvalidSpeed, language, speed)
+			}
+		}
+		for language, level := range p.Proficiency {
+			if _, ok := learnerUnknownShares[level]; !ok {
+				return fmt.Errorf("%w: proficiency in %s: %w", ErrInvalidProfile, language, ErrUnknownCEFRLevel)
+			}
+		}
+		switch {
+		case p.DifficultySensitivity < 0 || p.DifficultySensitivity >= 1:
+			return fmt.Errorf("%w: difficulty sensitivity must be in [0, 1), got %g", ErrInvalidProfile, p.DifficultySensitivity)
+		case p.VisualsFactor < 1:
+			return fmt.Errorf("%w: visuals factor must be at least 1, got %g", ErrInvalidProfile, p.VisualsFactor)
+		case p.SkimFactor <= 0 || p.SkimFactor > 1:
+			return fmt.Errorf("%w: skim factor must be in (0, 1], got %g", ErrInvalidProfile, p.SkimFactor)
+		}
+	}
+
+	for class, policy := range opts.ClassPolicies {
+		if _, err := ParseTokenClass(string(class)); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidClassPolicy, err)
+		}
+		if policy.Cost < 0 || math.IsNaN(policy.Cost) || math.IsInf(policy.Cost, 0) {
+			return fmt.Errorf("%w: cost of %s must be a non-negative number, got %g", ErrInvalidClassPolicy, class, policy.Cost)
+		}
+		if _, err := ParseSyllablePolicy(string(policy.Syllables)); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidClassPolicy, err)
+		}
+	}
+	return nil
+}
+
+// isPositive сообщает, является ли значение конечным положительным числом
+func isPositive(value float64) bool {
+	return value > 0 && !math.IsInf(value, 0)
+}
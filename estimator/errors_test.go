@@ -0,0 +1,62 @@
+package estimator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEstimateValidation(t *testing.T) {
+	text := "The cat sat on the mat. It was warm."
+	valid := Options{ReadingSpeed: 200, Workers: 2}
+
+	tests := []struct {
+		name string
+		text string
+		opts func(o *Options)
+		want error
+	}{
+		{"zero workers", text, func(o *Options) { o.Workers = 0 }, ErrInvalidWorkers},
+		{"negative speed", text, func(o *Options) { o.ReadingSpeed = -180 }, ErrInvalidSpeed},
+		{"NaN speed", text, func(o *Options) { o.ReadingSpeed = math.NaN() }, ErrInvalidSpeed},
+		{"negative characters per minute", text, func(o *Options) { o.CharactersPerMinute = -1 }, ErrInvalidSpeed},
+		{"negative hardest", text, func(o *Options) { o.Hardest = -1 }, ErrInvalidHardest},
+		{"unknown mode", text, func(o *Options) { o.Mode = "speedread" }, ErrUnknownReadingMode},
+		{"unknown backend", text, func(o *Options) { o.Syllables = "magic" }, ErrUnknownSyllableBackend},
+		{"profile language speed", text, func(o *Options) {
+			p := DefaultProfile()
+			p.LanguageSpeeds = map[string]float64{LanguageEnglish: 0}
+			o.Profile = &p
+		}, ErrInvalidSpeed},
+		{"profile skim factor", text, func(o *Options) {
+			p := DefaultProfile()
+			p.SkimFactor = 0
+			o.Profile = &p
+		}, ErrInvalidProfile},
+		{"negative cost", text, func(o *Options) {
+			o.ClassPolicies = map[TokenClass]ClassPolicy{TokenURL: {Cost: -1, Syllables: SyllablePolicySkip}}
+		}, ErrInvalidClassPolicy},
+		{"empty text", "", func(o *Options) {}, ErrEmptyText},
+		{"punctuation only", "... !!!", func(o *Options) {}, ErrEmptyText},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opts := valid
+			test.opts(&opts)
+			if _, err := Estimate(test.text, opts); !errors.Is(err, test.want) {
+				t.Errorf("Estimate() error = %v; want %v", err, test.want)
+			}
+		})
+	}
+
+	if _, err := EstimateReadingTimeParallel(text, 200, false, 0); !errors.Is(err, ErrInvalidWorkers) {
+		t.Errorf("EstimateReadingTimeParallel() with 0 workers error = %v; want ErrInvalidWorkers", err)
+	}
+	if _, _, err := AnalyzeStructure(text, Options{ReadingSpeed: -1}); !errors.Is(err, ErrInvalidSpeed) {
+		t.Errorf("AnalyzeStructure() with negative speed error = %v; want ErrInvalidSpeed", err)
+	}
+	if _, err := ParseTokenClass("hashtag"); !errors.Is(err, ErrUnknownTokenClass) {
+		t.Errorf("ParseTokenClass() error = %v; want ErrUnknownTokenClass", err)
+	}
+}
@@ -2,29 +2,75 @@ package estimator
 
 import (
 	"bufio"
-	"errors"
-	"math"
+	"io"
 	"os"
-	"regexp"
 	"strings"
 	"sync"
 	"unicode"
 )
 
 var (
-	russianVowels    = "аеёиоуыэюя"
-	englishVowels    = "aeiouy"
-	wordRegex        = regexp.MustCompile(`[\p{L}\p{N}]+(-[\p{L}\p{N}]+)*`)
-	sentenceEndRegex = regexp.MustCompile(`[.!?]+`)
+	russianVowels = "аеёиоуыэюя"
+	englishVowels = "aeiouy"
 )
 
 // содержит результаты анализа текста
 type Result struct {
-	ReadingTime        float64
-	WordCount          int
-	SentenceCount      int
-	SyllableCount      int
-	FleschKincaidIndex float64
+	ReadingTime        float64     `json:"reading_time" yaml:"reading_time" toml:"reading_time"`
+	WordCount          int         `json:"word_count" yaml:"word_count" toml:"word_count"`
+	SentenceCount      int         `json:"sentence_count" yaml:"sentence_count" toml:"sentence_count"`
+	SyllableCount      int         `json:"syllable_count" yaml:"syllable_count" toml:"syllable_count"`
+	FleschKincaidIndex float64     `json:"flesch_kincaid_index" yaml:"flesch_kincaid_index" toml:"flesch_kincaid_index"`
+	Mode               ReadingMode `json:"mode" yaml:"mode" toml:"mode"` // Модель чтения, по которой рассчитано ReadingTime
+
+	// Индекс читаемости неприменим: в тексте нет слов, для которых считаются слоги, например
+	// только китайский или японский текст. FleschKincaidIndex тогда равен 0 и оценкой не является.
+	FleschKincaidNotApplicable bool `json:"flesch_kincaid_not_applicable,omitempty" yaml:"flesch_kincaid_not_applicable,omitempty" toml:"flesch_kincaid_not_applicable,omitempty"`
+
+	// Время чтения во всех моделях, заполняется по запросу (см. Options.CompareModes)
+	ModeTimes map[ReadingMode]float64 `json:"mode_times,omitempty" yaml:"mode_times,omitempty" toml:"mode_times,omitempty"`
+
+	// Количество токенов каждого вида: слов, чисел, адресов и т.д.
+	TokenCounts map[TokenClass]int `json:"token_counts" yaml:"token_counts" toml:"token_counts"`
+
+	// Подробный анализ заполняется только по запросу (см. Options)
+	Paragraphs       []PassageStats `json:"paragraphs,omitempty" yaml:"paragraphs,omitempty" toml:"paragraphs,omitempty"`
+	Sentences        []PassageStats `json:"sentences,omitempty" yaml:"sentences,omitempty" toml:"sentences,omitempty"`
+	HardestSentences []PassageStats `json:"hardest_sentences,omitempty" yaml:"hardest_sentences,omitempty" toml:"hardest_sentences,omitempty"`
+
+	// Словарное разнообразие по языкам, заполняется по запросу (см. Options.Vocabulary)
+	Vocabulary []VocabularyStats `json:"vocabulary,omitempty" yaml:"vocabulary,omitempty" toml:"vocabulary,omitempty"`
+
+	// Примерный уровень CEFR по языкам, заполняется по запросу (см. Options.CEFR)
+	CEFR []CEFREstimate `json:"cefr,omitempty" yaml:"cefr,omitempty" toml:"cefr,omitempty"`
+}
+
+// AlgorithmVersion — версия алгоритма оценки. Ее нужно увеличивать при любом изменении,
+// от которого меняется Result, чтобы кэш результатов не возвращал устаревшие данные.
+const AlgorithmVersion = 5
+
+// Options задает параметры оценки текста
+type Options struct {
+	ReadingSpeed float64 // Скорость чтения в словах в минуту
+	HasVisuals   bool    // Текст содержит визуальные элементы
+	Workers      int     // Количество горутин для подсчета слогов
+	Detailed     bool    // Заполнять показатели по абзацам и предложениям
+	Hardest      int     // Сколько самых сложных предложений вернуть
+
+	Syllables     SyllableBackend            // Способ подсчета слогов, по умолчанию эвристика
+	ClassPolicies map[TokenClass]ClassPolicy // Правила для видов токенов поверх DefaultClassPolicies
+
+	Profile      *Profile    // Особенности читателя, по умолчанию DefaultProfile
+	Mode         ReadingMode // Модель чтения, по умолчанию обычное чтение
+	CompareModes bool        // Заполнять время чтения во всех моделях
+
+	Vocabulary     bool                // Рассчитывать показатели словарного разнообразия
+	FrequencyLists map[string][]string // Частотные списки слов по языкам для доли слов вне списка
+	CEFR           bool                // Определять примерный уровень CEFR текста
+
+	// Скорость чтения китайского и японского текста в символах в минуту;
+	// 0 — ChineseCharactersPerMinute или JapaneseCharactersPerMinute в зависимости от текста
+	CharactersPerMinute float64
 }
 
 func isRussianWord(word string) bool {
@@ -96,43 +142,71 @@ func CountSyllables(word string) int {
 	return max(syllables, 1)
 }
 
-// CountWords подсчитывает количество слов в тексте
+// CountWords подсчитывает количество слов в тексте. Эмодзи словами не считаются.
 func CountWords(text string) (int, []string) {
-	words := wordRegex.FindAllString(text, -1)
+	var words []string
+	for _, token := range Tokenize(text) {
+		if token.Class != TokenEmoji {
+			words = append(words, token.Text)
+		}
+	}
 	return len(words), words
 }
 
 // CountSentences подсчитывает количество предложений в тексте
 func CountSentences(text string) int {
-	sentences := sentenceEndRegex.Split(strings.TrimSpace(text), -1)
-	count := 0
-	for _, s := range sentences {
-		if strings.TrimSpace(s) != "" {
-			count++
-		}
-	}
-	return count
+	return len(SplitSentences(text))
 }
 
+// minFleschWords — наименьшее число слов, для которого формула Флеша-Кинкейда дает осмысленный результат
+const minFleschWords = 3
+
 // FleschKincaidIndex рассчитывает индекс Флеша-Кинкейда
 func FleschKincaidIndex(wordsCount, sentencesCount, syllablesCount float64) float64 {
 	if wordsCount == 0 || sentencesCount == 0 {
 		return 0
 	}
 	// Для очень коротких текстов делаем минимальную коррекцию, чтобы избежать слишком больших значений
-	if wordsCount < 3 || sentencesCount < 2 {
+	if wordsCount < minFleschWords || sentencesCount < 2 {
 		return 100
 	}
+	return fleschScore(wordsCount, sentencesCount, syllablesCount)
+}
+
+// fleschScore рассчитывает индекс по формуле без поправки для коротких текстов
+func fleschScore(wordsCount, sentencesCount, syllablesCount float64) float64 {
 	return 206.835 - 1.015*(wordsCount/sentencesCount) - 84.6*(syllablesCount/wordsCount)
 }
 
-// EstimateReadingTimeParallel оценивает время чтения текста с использованием параллельной обработки
+// adjustSpeed снижает скорость чтения для сложного текста на долю sensitivity
+func adjustSpeed(readingSpeed, fkIndex, sensitivity float64) float64 {
+	if fkIndex < 60 {
+		return readingSpeed * (1 - sensitivity) // Сложный текст
+	}
+	return readingSpeed
+}
+
+// EstimateReadingTimeParallel оценивает время чтения текста с использованием параллельной обработки.
+// Неверные параметры возвращают ErrInvalidSpeed или ErrInvalidWorkers, текст без слов — ErrEmptyText.
 func EstimateReadingTimeParallel(text string, readingSpeed float64, hasVisuals bool, workerCount int) (Result, error) {
-	wordsCount, words := CountWords(text)
+	return Estimate(text, Options{ReadingSpeed: readingSpeed, HasVisuals: hasVisuals, Workers: workerCount})
+}
+
+// Estimate оценивает время чтения текста и, если нужно, рассчитывает показатели по абзацам и предложениям
+func Estimate(text string, opts Options) (Result, error) {
+	if err := opts.Validate(); err != nil {
+		return Result{}, err
+	}
+	workerCount := opts.Workers
+
+	all := Tokenize(text)
+	tokens := measureTokens(all, opts.classPolicies())
+	words := tokens.readable
+	wordsCount := tokens.wordCount
 	sentencesCount := CountSentences(text)
 
 	if wordsCount == 0 || sentencesCount == 0 {
-		return Result{}, errors.New("text is empty or invalid")
+		return Result{}, ErrEmptyText
 	}
 
 	// Параллельный подсчет слогов
@@ -147,7 +221,7 @@ func EstimateReadingTimeParallel(text string, readingSpeed float64, hasVisuals b
 			end := min(start+chunkSize, len(words))
 			localSum := 0
 			for j := start; j < end; j++ {
-				localSum += CountSyllables(words[j])
+				localSum += CountSyllablesWith(words[j], opts.Syllables)
 			}
 			syllablesChan <- localSum
 		}(i * chunkSize)
@@ -158,31 +232,51 @@ func EstimateReadingTimeParallel(text string, readingSpeed float64, hasVisuals b
 		close(syllablesChan)
 	}()
 
-	syllablesCount := 0
+	syllablesCount := tokens.spelledLength
 	for count := range syllablesChan {
 		syllablesCount += count
 	}
 
-	fkIndex := FleschKincaidIndex(float64(wordsCount), float64(sentencesCount), float64(syllablesCount))
+	// Числа, адреса и идентификаторы в индекс читаемости не входят, но время на их чтение учитывается
+	fkIndex := FleschKincaidIndex(float64(tokens.readableCount()), float64(sentencesCount), float64(syllablesCount))
 
-	adjustedSpeed := readingSpeed
-	if fkIndex < 60 {
-		adjustedSpeed *= 0.8 // Сложный текст
-	}
-
-	readingTime := float64(wordsCount) / adjustedSpeed
+	mode, _ := ParseReadingMode(string(opts.Mode))
+	times := readingTimes(text, tokens, opts, fkIndex, syllablesCount, sentencesCount)
 
-	if hasVisuals {
-		readingTime *= 1.1
-	}
-
-	return Result{
-		ReadingTime:        math.Round(readingTime*100) / 100,
+	result := Result{
+		ReadingTime:        times[mode],
+		Mode:               mode,
 		WordCount:          wordsCount,
 		SentenceCount:      sentencesCount,
 		SyllableCount:      syllablesCount,
 		FleschKincaidIndex: fkIndex,
-	}, nil
+		TokenCounts:        tokens.counts,
+
+		FleschKincaidNotApplicable: tokens.readableCount() == 0,
+	}
+	if opts.CompareModes {
+		result.ModeTimes = times
+	}
+
+	if opts.Detailed || opts.Hardest > 0 {
+		paragraphs, sentences, err := AnalyzeStructure(text, opts)
+		if err != nil {
+			return Result{}, err
+		}
+		if opts.Detailed {
+			result.Paragraphs = paragraphs
+			result.Sentences = sentences
+		}
+		result.HardestSentences = HardestSentences(sentences, opts.Hardest)
+	}
+	if opts.Vocabulary {
+		result.Vocabulary = AnalyzeVocabulary(all, opts.FrequencyLists)
+	}
+	if opts.CEFR {
+		result.CEFR = EstimateCEFR(text, opts.Syllables)
+	}
+
+	return result, nil
 }
 
 // ReadTextFromFile читает текст из файла
@@ -193,11 +287,17 @@ func ReadTextFromFile(filePath string) (string, error) {
 	}
 	defer file.Close()
 
+	return ReadText(file)
+}
+
+// ReadText читает текст построчно, приводя переводы строк к \n
+func ReadText(r io.Reader) (string, error) {
 	var text strings.Builder
-	scanner := bufio.NewScanner(file)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		text.WriteString(scanner.Text())
-		text.WriteString(" ")
+		// Сохраняем переносы строк, чтобы можно было выделить абзацы
+		text.WriteString("\n")
 	}
 
 	if err := scanner.Err(); err != nil {
@@ -146,6 +146,7 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 		hasVisuals   bool
 		workerCount  int
 		expectError  bool
+		wordCount    int
 	}{
 		{
 			name:         "Normal text",
@@ -154,6 +155,7 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 			hasVisuals:   false,
 			workerCount:  2,
 			expectError:  false,
+			wordCount:    12,
 		},
 		{
 			name:         "Short text with visuals",
@@ -162,6 +164,7 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 			hasVisuals:   true,
 			workerCount:  1,
 			expectError:  false,
+			wordCount:    2,
 		},
 		{
 			name:         "Empty text",
@@ -178,6 +181,7 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 			hasVisuals:   false,
 			workerCount:  3,
 			expectError:  false,
+			wordCount:    25,
 		},
 		{
 			name:         "Single word, single sentence",
@@ -186,6 +190,7 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 			hasVisuals:   false,
 			workerCount:  1,
 			expectError:  false,
+			wordCount:    1,
 		},
 		{
 			name:         "Text with non-word characters",
@@ -202,6 +207,7 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 			hasVisuals:   false,
 			workerCount:  8,
 			expectError:  false,
+			wordCount:    8000,
 		},
 		{
 			name:         "Text with visuals",
@@ -210,6 +216,7 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 			hasVisuals:   true,
 			workerCount:  2,
 			expectError:  false,
+			wordCount:    4,
 		},
 	}
 
@@ -228,9 +235,8 @@ func TestEstimateReadingTimeParallel(t *testing.T) {
 				if result.ReadingTime < 0 {
 					t.Errorf("EstimateReadingTimeParallel() returned invalid reading time: %f", result.ReadingTime)
 				}
-				expectedWordCount := len(wordRegex.FindAllString(test.text, -1))
-				if result.WordCount != expectedWordCount {
-					t.Errorf("Word count mismatch. Got %d, want %d", result.WordCount, expectedWordCount)
+				if result.WordCount != test.wordCount {
+					t.Errorf("Word count mismatch. Got %d, want %d", result.WordCount, test.wordCount)
 				}
 				expectedSentences := CountSentences(test.text)
 				if result.SentenceCount != expectedSentences {
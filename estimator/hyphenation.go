@@ -0,0 +1,102 @@
+package estimator
+
+import (
+	"bufio"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Hyphenator расставляет переносы по алгоритму Лианга с шаблонами в формате TeX
+type Hyphenator struct {
+	patterns map[string][]int
+	maxLen   int
+	leftMin  int
+	rightMin int
+}
+
+// NewHyphenator разбирает шаблоны переносов (по одному на строку, например "1ba" или ".ach4").
+// Строки, начинающиеся с "%", считаются комментариями. leftMin и rightMin задают минимальное
+// количество букв до первого и после последнего переноса.
+func NewHyphenator(patterns string, leftMin, rightMin int) *Hyphenator {
+	h := &Hyphenator{
+		patterns: make(map[string][]int),
+		leftMin:  leftMin,
+		rightMin: rightMin,
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(patterns))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "%") {
+			continue
+		}
+
+		var letters []rune
+		values := []int{0}
+		for _, r := range line {
+			if unicode.IsDigit(r) {
+				values[len(values)-1] = int(r - '0')
+				continue
+			}
+			letters = append(letters, r)
+			values = append(values, 0)
+		}
+		h.patterns[string(letters)] = values
+		h.maxLen = max(h.maxLen, len(letters))
+	}
+	return h
+}
+
+// Hyphenate делит слово на части в местах возможных переносов
+func (h *Hyphenator) Hyphenate(word string) []string {
+	points := h.points(word)
+	runes := []rune(word)
+
+	parts := make([]string, 0, len(points)+1)
+	prev := 0
+	for _, p := range points {
+		parts = append(parts, string(runes[prev:p]))
+		prev = p
+	}
+	return append(parts, string(runes[prev:]))
+}
+
+// points возвращает индексы букв слова, перед которыми возможен перенос
+func (h *Hyphenator) points(word string) []int {
+	n := utf8.RuneCountInString(word)
+	if n < h.leftMin+h.rightMin {
+		return nil
+	}
+
+	// Слово с границами: ".слово."
+	runes := make([]rune, 0, n+2)
+	runes = append(runes, '.')
+	for _, r := range strings.ToLower(word) {
+		runes = append(runes, r)
+	}
+	runes = append(runes, '.')
+
+	// values[i] — значение шаблонов между runes[i-1] и runes[i]
+	values := make([]int, len(runes)+1)
+	for i := range runes {
+		for j := i + 1; j <= min(len(runes), i+h.maxLen); j++ {
+			pattern, ok := h.patterns[string(runes[i:j])]
+			if !ok {
+				continue
+			}
+			for k, v := range pattern {
+				values[i+k] = max(values[i+k], v)
+			}
+		}
+	}
+
+	var points []int
+	for i := h.leftMin; i <= n-h.rightMin; i++ {
+		// Перед буквой слова с индексом i стоит runes[i], значение между ними — values[i+1]
+		if values[i+1]%2 == 1 {
+			points = append(points, i)
+		}
+	}
+	return points
+}
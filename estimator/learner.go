@@ -0,0 +1,54 @@
+package estimator
+
+// UnknownWordFactor — во сколько раз дольше читается слово, которого читатель не знает:
+// его приходится разбирать по контексту или искать в словаре
+const UnknownWordFactor = 3
+
+// learnerUnknownShares — доля незнакомых читателю слов среди слов базового словаря
+// и среди редких слов (вне базового словаря) для каждого уровня владения языком
+var learnerUnknownShares = map[CEFRLevel]struct{ core, rare float64 }{
+	CEFRA1: {core: 0.3, rare: 1},
+	CEFRA2: {core: 0.1, rare: 0.85},
+	CEFRB1: {core: 0, rare: 0.6},
+	CEFRB2: {core: 0, rare: 0.4},
+	CEFRC1: {core: 0, rare: 0.2},
+	CEFRC2: {core: 0, rare: 0.05},
+}
+
+// learnerFactors возвращает, во сколько раз медленнее читатель с уровнем proficiency читает
+// слова каждого языка по сравнению с носителем. Замедление растет с долей редких слов,
+// которые читатель этого уровня, скорее всего, не знает. Языки без уровня читаются как родные.
+func learnerFactors(tokens []Token, proficiency map[string]CEFRLevel) map[string]float64 {
+	if len(proficiency) == 0 {
+		return nil
+	}
+	coreVocabularyOnce.Do(loadCoreVocabulary)
+
+	words := make(map[string]int)
+	rare := make(map[string]int)
+	for _, token := range tokens {
+		if token.Class != TokenWord {
+			continue
+		}
+		language := wordLanguage(token.Text)
+		if _, ok := proficiency[language]; !ok {
+			continue
+		}
+		words[language]++
+		if !coreVocabulary[language][StemLanguage(token.Text, language)] {
+			rare[language]++
+		}
+	}
+
+	factors := make(map[string]float64, len(words))
+	for language, count := range words {
+		shares, ok := learnerUnknownShares[proficiency[language]]
+		if !ok {
+			continue
+		}
+		rareShare := float64(rare[language]) / float64(count)
+		unknown := (1-rareShare)*shares.core + rareShare*shares.rare
+		factors[language] = 1 + (UnknownWordFactor-1)*unknown
+	}
+	return factors
+}
@@ -0,0 +1,49 @@
+package estimator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLearnerReadingTime(t *testing.T) {
+	text := "The epistemological implications of this paradigm shift are considerable. " +
+		"Contemporary philosophers emphasise the contingent character of scientific rationality. " +
+		"Methodological pluralism constitutes an indispensable prerequisite for intellectual progress."
+
+	estimate := func(level CEFRLevel) float64 {
+		profile := DefaultProfile()
+		if level != "" {
+			profile.Proficiency = map[string]CEFRLevel{LanguageEnglish: level}
+		}
+		result, err := Estimate(text, Options{ReadingSpeed: 20, Workers: 1, Profile: &profile})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.ReadingTime
+	}
+
+	native, c2, b1, a1 := estimate(""), estimate(CEFRC2), estimate(CEFRB1), estimate(CEFRA1)
+	if !(native < c2 && c2 < b1 && b1 < a1) {
+		t.Errorf("reading times native %.2f, C2 %.2f, B1 %.2f, A1 %.2f; want them to grow as proficiency falls", native, c2, b1, a1)
+	}
+	if a1 > native*UnknownWordFactor {
+		t.Errorf("A1 reading time %.2f is more than %d times the native time %.2f", a1, UnknownWordFactor, native)
+	}
+}
+
+func TestLearnerFactors(t *testing.T) {
+	tokens := Tokenize("I have a big dog. Я живу в доме.")
+	factors := learnerFactors(tokens, map[string]CEFRLevel{LanguageEnglish: CEFRB1})
+	if len(factors) != 1 || factors[LanguageEnglish] != 1 {
+		t.Errorf("factors = %v, want only en without slowdown for core vocabulary", factors)
+	}
+}
+
+func TestValidateProficiency(t *testing.T) {
+	profile := DefaultProfile()
+	profile.Proficiency = map[string]CEFRLevel{LanguageRussian: "B3"}
+	err := Options{ReadingSpeed: 200, Workers: 1, Profile: &profile}.Validate()
+	if !errors.Is(err, ErrInvalidProfile) || !errors.Is(err, ErrUnknownCEFRLevel) {
+		t.Errorf("Validate() = %v, want ErrInvalidProfile and ErrUnknownCEFRLevel", err)
+	}
+}
@@ -0,0 +1,163 @@
+package estimator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ReadingMode определяет модель чтения
+type ReadingMode string
+
+const (
+	// ModeSkim — беглый просмотр: заголовки читаются полностью, остальной текст — за долю времени из профиля
+	ModeSkim ReadingMode = "skim"
+	// ModeNormal — обычное чтение (по умолчанию)
+	ModeNormal ReadingMode = "normal"
+	// ModeStudy — внимательное изучение: скорость ниже, на формулы и таблицы уходит дополнительное время
+	ModeStudy ReadingMode = "study"
+	// ModeAloud — чтение вслух: время зависит от количества слогов, а не от скорости чтения про себя
+	ModeAloud ReadingMode = "aloud"
+)
+
+// Параметры моделей чтения
+const (
+	StudySpeedFactor = 0.6  // Доля обычной скорости при изучении текста
+	StudyFormulaTime = 0.33 // Дополнительные минуты на каждую формулу
+	StudyTableTime   = 0.75 // Дополнительные минуты на каждую таблицу
+
+	// Дикторы читают около 150 слов в минуту, то есть примерно 3.75 слога в секунду
+	AloudSyllablesPerSecond  = 3.75
+	AloudSyllablesPerWord    = 1.5 // Слоги в числах, адресах и других токенах без подсчета слогов
+	AloudCharactersPerSecond = 4   // Китайский и японский: около одного слога на символ
+	AloudSentencePause       = 0.3 // Пауза после предложения в секундах
+)
+
+var (
+	markdownHeadingRegex = regexp.MustCompile(`^#{1,6}\s+\S`)
+	setextUnderlineRegex = regexp.MustCompile(`^(=+|-+)\s*$`)
+	formulaRegex         = regexp.MustCompile(`(?s)\$\$.+?\$\$|\\\[.+?\\\]|\\begin\{(?:equation|align|gather|multline)\*?\}.+?\\end\{(?:equation|align|gather|multline)\*?\}|\$[^$\s][^$\n]*?\$`)
+)
+
+// ReadingModes возвращает все модели чтения
+func ReadingModes() []ReadingMode {
+	return []ReadingMode{ModeSkim, ModeNormal, ModeStudy, ModeAloud}
+}
+
+// ParseReadingMode разбирает название модели чтения; пустая строка означает обычное чтение
+func ParseReadingMode(name string) (ReadingMode, error) {
+	switch mode := ReadingMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case "":
+		return ModeNormal, nil
+	case ModeSkim, ModeNormal, ModeStudy, ModeAloud:
+		return mode, nil
+	case "careful":
+		return ModeStudy, nil
+	case "read-aloud", "speech":
+		return ModeAloud, nil
+	default:
+		return "", fmt.Errorf("%w %q (supported: %s, %s, %s, %s)", ErrUnknownReadingMode, name, ModeSkim, ModeNormal, ModeStudy, ModeAloud)
+	}
+}
+
+// layout — элементы разметки текста, которые влияют на время чтения в разных моделях
+type layout struct {
+	headings string // Текст заголовков через перевод строки
+	formulas int
+	tables   int
+}
+
+// analyzeLayout находит в тексте заголовки, формулы LaTeX и таблицы (Markdown или через табуляцию)
+func analyzeLayout(text string) layout {
+	var l layout
+	l.formulas = len(formulaRegex.FindAllStringIndex(text, -1))
+
+	var headings []string
+	lines := strings.Split(text, "\n")
+	tableLines := 0
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "|") || strings.Count(trimmed, "\t") >= 2 {
+			tableLines++
+		} else {
+			if tableLines >= 2 {
+				l.tables++
+			}
+			tableLines = 0
+		}
+
+		switch {
+		case markdownHeadingRegex.MatchString(trimmed):
+			headings = append(headings, strings.TrimLeft(trimmed, "# "))
+		case trimmed != "" && i+1 < len(lines) && setextUnderlineRegex.MatchString(strings.TrimSpace(lines[i+1])):
+			headings = append(headings, trimmed)
+		case isStandaloneHeading(lines, i):
+			headings = append(headings, trimmed)
+		}
+	}
+	if tableLines >= 2 {
+		l.tables++
+	}
+
+	l.headings = strings.Join(headings, "\n")
+	return l
+}
+
+// isStandaloneHeading сообщает, похожа ли строка на заголовок без разметки: короткая отдельная строка
+// без знака конца предложения, после которой идет пустая строка и текст
+func isStandaloneHeading(lines []string, i int) bool {
+	line := strings.TrimSpace(lines[i])
+	if line == "" || (i > 0 && strings.TrimSpace(lines[i-1]) != "") {
+		return false
+	}
+	if i+2 >= len(lines) || strings.TrimSpace(lines[i+1]) != "" || strings.TrimSpace(lines[i+2]) == "" {
+		return false
+	}
+	// Заголовок начинается с буквы или цифры, что отсекает формулы, таблицы и списки
+	first, _ := utf8.DecodeRuneInString(line)
+	last, _ := utf8.DecodeLastRuneInString(line)
+	if (!unicode.IsLetter(first) && !unicode.IsDigit(first)) || isTerminator(last) || last == ':' || last == ',' || last == ';' {
+		return false
+	}
+	words, _ := CountWords(line)
+	return words > 0 && words <= 10
+}
+
+// readingTimes рассчитывает время чтения в каждой модели, округленное до сотых минуты
+func readingTimes(text string, tokens tokenStats, opts Options, fkIndex float64, syllables, sentences int) map[ReadingMode]float64 {
+	profile := opts.profile()
+	visuals := 1.0
+	if opts.HasVisuals {
+		visuals = profile.VisualsFactor
+	}
+
+	l := analyzeLayout(text)
+	normal := tokens.minutes(opts, fkIndex)
+
+	// Заголовки читаются с обычной скоростью, остальной текст — за долю времени
+	headings := 0.0
+	if l.headings != "" {
+		headings = min(measureTokens(Tokenize(l.headings), opts.classPolicies()).minutes(opts, fkIndex), normal)
+	}
+	skim := headings + (normal-headings)*profile.SkimFactor
+
+	study := normal/StudySpeedFactor + float64(l.formulas)*StudyFormulaTime + float64(l.tables)*StudyTableTime
+
+	spoken := float64(syllables) + tokens.skippedCost*AloudSyllablesPerWord
+	aloud := spoken/(AloudSyllablesPerSecond*60) + tokens.cjkCost/(AloudCharactersPerSecond*60) + float64(sentences)*AloudSentencePause/60
+
+	return map[ReadingMode]float64{
+		ModeSkim:   roundMinutes(skim * visuals),
+		ModeNormal: roundMinutes(normal * visuals),
+		ModeStudy:  roundMinutes(study * visuals),
+		ModeAloud:  roundMinutes(aloud),
+	}
+}
+
+func roundMinutes(minutes float64) float64 {
+	return math.Round(minutes*100) / 100
+}
@@ -0,0 +1,54 @@
+package estimator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeLayout(t *testing.T) {
+	text := "# Introduction\n\nSome text with $x^2$ inline.\n\nResults\n\nThe table below.\n\n| a | b |\n|---|---|\n| 1 | 2 |\n\n$$\\sum_i x_i$$\n\nSummary\n=======\n\nDone."
+
+	l := analyzeLayout(text)
+	if l.headings != "Introduction\nResults\nSummary" {
+		t.Errorf("headings = %q; want Introduction, Results and Summary", l.headings)
+	}
+	if l.formulas != 2 {
+		t.Errorf("formulas = %d; want 2", l.formulas)
+	}
+	if l.tables != 1 {
+		t.Errorf("tables = %d; want 1", l.tables)
+	}
+}
+
+func TestEstimateModes(t *testing.T) {
+	text := "# Heading\n\n" + strings.Repeat("The quick brown fox jumps over the lazy dog. ", 40) + "\n\n$$E = mc^2$$"
+
+	result, err := Estimate(text, Options{ReadingSpeed: 200, Workers: 2, CompareModes: true})
+	if err != nil {
+		t.Fatalf("Estimate() returned error: %v", err)
+	}
+	if result.Mode != ModeNormal || result.ReadingTime != result.ModeTimes[ModeNormal] {
+		t.Errorf("Mode = %q, ReadingTime = %.2f; want normal time %.2f", result.Mode, result.ReadingTime, result.ModeTimes[ModeNormal])
+	}
+
+	times := result.ModeTimes
+	if !(times[ModeSkim] < times[ModeNormal] && times[ModeNormal] < times[ModeStudy]) {
+		t.Errorf("ModeTimes = %v; want skim < normal < study", times)
+	}
+	// Около 150 слов в минуту вслух
+	if wpm := float64(result.WordCount) / times[ModeAloud]; wpm < 120 || wpm > 180 {
+		t.Errorf("Aloud time %.2f gives %.0f wpm; want about 150", times[ModeAloud], wpm)
+	}
+
+	aloud, err := Estimate(text, Options{ReadingSpeed: 200, Workers: 2, Mode: ModeAloud})
+	if err != nil {
+		t.Fatalf("Estimate() returned error: %v", err)
+	}
+	if aloud.ReadingTime != times[ModeAloud] || aloud.ModeTimes != nil {
+		t.Errorf("Estimate(aloud) = %.2f with ModeTimes %v; want %.2f without ModeTimes", aloud.ReadingTime, aloud.ModeTimes, times[ModeAloud])
+	}
+
+	if _, err := Estimate(text, Options{ReadingSpeed: 200, Workers: 2, Mode: "speedread"}); err == nil {
+		t.Error("Estimate() expected an error for an unknown mode")
+	}
+}
@@ -0,0 +1,154 @@
+package estimator
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	paragraphBreakRegex = regexp.MustCompile(`\n[ \t\r]*\n`)
+	lineBreakRegex      = regexp.MustCompile(`\n`)
+)
+
+// Span — фрагмент исходного текста с байтовыми смещениями начала и конца
+type Span struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// PassageStats содержит показатели отдельного фрагмента текста (абзаца или предложения)
+type PassageStats struct {
+	Paragraph          int     `json:"paragraph" yaml:"paragraph" toml:"paragraph"` // Номер абзаца, начиная с 1
+	Start              int     `json:"start" yaml:"start" toml:"start"`
+	End                int     `json:"end" yaml:"end" toml:"end"`
+	Text               string  `json:"text" yaml:"text" toml:"text"`
+	WordCount          int     `json:"word_count" yaml:"word_count" toml:"word_count"`
+	SentenceCount      int     `json:"sentence_count" yaml:"sentence_count" toml:"sentence_count"`
+	SyllableCount      int     `json:"syllable_count" yaml:"syllable_count" toml:"syllable_count"`
+	FleschKincaidIndex float64 `json:"flesch_kincaid_index" yaml:"flesch_kincaid_index" toml:"flesch_kincaid_index"`
+	ReadingTime        float64 `json:"reading_time" yaml:"reading_time" toml:"reading_time"`
+}
+
+// SplitParagraphs делит текст на абзацы по пустым строкам.
+// Если пустых строк в тексте нет, абзацем считается каждая непустая строка.
+func SplitParagraphs(text string) []Span {
+	separators := paragraphBreakRegex.FindAllStringIndex(text, -1)
+	if len(separators) == 0 {
+		separators = lineBreakRegex.FindAllStringIndex(text, -1)
+	}
+
+	var spans []Span
+	start := 0
+	for _, loc := range separators {
+		spans = appendSpan(spans, text, start, loc[0])
+		start = loc[1]
+	}
+	return appendSpan(spans, text, start, len(text))
+}
+
+// appendSpan добавляет фрагмент text[start:end] без окружающих пробелов, если он не пустой
+func appendSpan(spans []Span, text string, start, end int) []Span {
+	fragment := text[start:end]
+	trimmed := strings.TrimSpace(fragment)
+	if trimmed == "" {
+		return spans
+	}
+	offset := start + strings.Index(fragment, trimmed)
+	return append(spans, Span{Start: offset, End: offset + len(trimmed), Text: trimmed})
+}
+
+// Position возвращает строку и столбец байтового смещения в тексте; оба считаются с 1, столбец — в символах
+func Position(text string, offset int) (line, column int) {
+	offset = min(max(offset, 0), len(text))
+	before := text[:offset]
+	lineStart := strings.LastIndexByte(before, '\n') + 1
+	return strings.Count(before, "\n") + 1, utf8.RuneCountInString(before[lineStart:]) + 1
+}
+
+// AnalyzePassage рассчитывает показатели фрагмента текста с учетом скорости чтения и способа подсчета слогов из opts.
+// Параметры не проверяются: для непроверенных opts используйте AnalyzeStructure или Options.Validate.
+func AnalyzePassage(span Span, opts Options) PassageStats {
+	tokens := measureTokens(Tokenize(span.Text), opts.classPolicies())
+	wordsCount := tokens.wordCount
+	sentencesCount := max(CountSentences(span.Text), 1)
+
+	syllablesCount := tokens.spelledLength
+	for _, word := range tokens.readable {
+		syllablesCount += CountSyllablesWith(word, opts.Syllables)
+	}
+
+	stats := PassageStats{
+		Start:         span.Start,
+		End:           span.End,
+		Text:          span.Text,
+		WordCount:     wordsCount,
+		SentenceCount: sentencesCount,
+		SyllableCount: syllablesCount,
+	}
+	if wordsCount == 0 {
+		return stats
+	}
+
+	// Поправка FleschKincaidIndex для одного предложения сделала бы все отдельные предложения одинаково простыми,
+	// поэтому здесь учитывается только число слов. Заголовок из одного-двух слов или фрагмент только из чисел,
+	// адресов или иероглифов оценивать нечем, поэтому он считается простым.
+	stats.FleschKincaidIndex = 100
+	if readable := tokens.readableCount(); readable >= minFleschWords {
+		stats.FleschKincaidIndex = fleschScore(float64(readable), float64(sentencesCount), float64(syllablesCount))
+	}
+	stats.ReadingTime = math.Round(tokens.minutes(opts, stats.FleschKincaidIndex)*100) / 100
+	return stats
+}
+
+// AnalyzePassages рассчитывает показатели для каждого фрагмента
+func AnalyzePassages(spans []Span, opts Options) []PassageStats {
+	stats := make([]PassageStats, len(spans))
+	for i, span := range spans {
+		stats[i] = AnalyzePassage(span, opts)
+	}
+	return stats
+}
+
+// AnalyzeStructure рассчитывает показатели каждого абзаца и каждого предложения текста.
+// Смещения предложений отсчитываются от начала всего текста.
+func AnalyzeStructure(text string, opts Options) (paragraphs, sentences []PassageStats, err error) {
+	if err := opts.validateReading(); err != nil {
+		return nil, nil, err
+	}
+
+	paragraphs = AnalyzePassages(SplitParagraphs(text), opts)
+	for i := range paragraphs {
+		paragraphs[i].Paragraph = i + 1
+		for _, span := range SplitSentences(paragraphs[i].Text) {
+			span.Start += paragraphs[i].Start
+			span.End += paragraphs[i].Start
+			stats := AnalyzePassage(span, opts)
+			stats.Paragraph = i + 1
+			sentences = append(sentences, stats)
+		}
+	}
+	return paragraphs, sentences, nil
+}
+
+// HardestSentences возвращает не более n предложений с наименьшим индексом читаемости,
+// начиная с самого сложного. Предложения без слов не учитываются.
+func HardestSentences(sentences []PassageStats, n int) []PassageStats {
+	if n <= 0 {
+		return nil
+	}
+
+	ranked := make([]PassageStats, 0, len(sentences))
+	for _, s := range sentences {
+		if s.WordCount > 0 {
+			ranked = append(ranked, s)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].FleschKincaidIndex < ranked[j].FleschKincaidIndex
+	})
+	return ranked[:min(n, len(ranked))]
+}
@@ -0,0 +1,153 @@
+package estimator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSplitParagraphs(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Первый абзац.\n\nВторой абзац.", []string{"Первый абзац.", "Второй абзац."}},
+		{"Line one\nline two\n  \n\nNext paragraph\n", []string{"Line one\nline two", "Next paragraph"}},
+		{"Каждая строка\nотдельный абзац\n", []string{"Каждая строка", "отдельный абзац"}},
+		{"   ", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("text=%q", test.text), func(t *testing.T) {
+			spans := SplitParagraphs(test.text)
+			if len(spans) != len(test.expected) {
+				t.Fatalf("SplitParagraphs(%q) returned %d paragraphs; want %d", test.text, len(spans), len(test.expected))
+			}
+			for i, span := range spans {
+				if span.Text != test.expected[i] {
+					t.Errorf("Paragraph %d is %q; want %q", i, span.Text, test.expected[i])
+				}
+				if test.text[span.Start:span.End] != span.Text {
+					t.Errorf("Paragraph %d offsets [%d:%d] do not match its text", i, span.Start, span.End)
+				}
+			}
+		})
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Это первое предложение. Это второе! А это третье?", []string{"Это первое предложение.", "Это второе!", "А это третье?"}},
+		{"Вопрос?! Крик!!!", []string{"Вопрос?!", "Крик!!!"}},
+		{"Без точки в конце", []string{"Без точки в конце"}},
+		{"", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("text=%q", test.text), func(t *testing.T) {
+			spans := SplitSentences(test.text)
+			if len(spans) != len(test.expected) {
+				t.Fatalf("SplitSentences(%q) returned %d sentences; want %d", test.text, len(spans), len(test.expected))
+			}
+			for i, span := range spans {
+				if span.Text != test.expected[i] {
+					t.Errorf("Sentence %d is %q; want %q", i, span.Text, test.expected[i])
+				}
+				if test.text[span.Start:span.End] != span.Text {
+					t.Errorf("Sentence %d offsets [%d:%d] do not match its text", i, span.Start, span.End)
+				}
+			}
+		})
+	}
+}
+
+func TestPosition(t *testing.T) {
+	text := "первая\nвторая строка"
+	offset := strings.Index(text, "строка")
+	if line, column := Position(text, offset); line != 2 || column != 8 {
+		t.Errorf("Position = %d:%d, want 2:8", line, column)
+	}
+}
+
+func TestAnalyzePassage(t *testing.T) {
+	easy := AnalyzePassage(Span{Text: "The cat sat on the mat."}, Options{ReadingSpeed: 200})
+	hard := AnalyzePassage(Span{Text: "Notwithstanding considerable institutional opposition, the administration implemented restructuring."}, Options{ReadingSpeed: 200})
+
+	if easy.WordCount != 6 || easy.SentenceCount != 1 {
+		t.Errorf("AnalyzePassage() counted %d words and %d sentences; want 6 and 1", easy.WordCount, easy.SentenceCount)
+	}
+	if easy.FleschKincaidIndex <= hard.FleschKincaidIndex {
+		t.Errorf("Simple sentence index %.2f must be higher than complex sentence index %.2f",
+			easy.FleschKincaidIndex, hard.FleschKincaidIndex)
+	}
+	if easy.ReadingTime <= 0 {
+		t.Errorf("AnalyzePassage() returned invalid reading time: %f", easy.ReadingTime)
+	}
+
+	empty := AnalyzePassage(Span{Text: "..."}, Options{ReadingSpeed: 200})
+	if empty.WordCount != 0 || empty.ReadingTime != 0 {
+		t.Errorf("AnalyzePassage() of punctuation must be empty, got %+v", empty)
+	}
+}
+
+func TestHardestSentencesSkipsHeadings(t *testing.T) {
+	text := "# Introduction\n\nThe committee reviewed the proposal and approved the new budget for the next year."
+
+	_, sentences, err := AnalyzeStructure(text, Options{ReadingSpeed: 200})
+	if err != nil {
+		t.Fatalf("AnalyzeStructure() returned an unexpected error: %v", err)
+	}
+	if len(sentences) != 2 {
+		t.Fatalf("AnalyzeStructure() returned %d sentences; want 2", len(sentences))
+	}
+	// Формула для одного длинного слова дает индекс меньше -100, хотя заголовок читается легко
+	if heading := sentences[0]; heading.FleschKincaidIndex != 100 {
+		t.Errorf("Heading %q index = %.2f; want 100", heading.Text, heading.FleschKincaidIndex)
+	}
+	hardest := HardestSentences(sentences, 1)
+	if len(hardest) != 1 || hardest[0].Paragraph != 2 {
+		t.Errorf("HardestSentences() = %+v; want the long sentence", hardest)
+	}
+}
+
+func TestEstimateDetailed(t *testing.T) {
+	text := "The cat sat on the mat. It was a sunny day.\n\n" +
+		"Notwithstanding considerable institutional opposition, the administration implemented restructuring. Everyone agreed."
+
+	result, err := Estimate(text, Options{ReadingSpeed: 200, Workers: 2, Detailed: true, Hardest: 2})
+	if err != nil {
+		t.Fatalf("Estimate() returned an unexpected error: %v", err)
+	}
+
+	if len(result.Paragraphs) != 2 {
+		t.Fatalf("Estimate() returned %d paragraphs; want 2", len(result.Paragraphs))
+	}
+	if len(result.Sentences) != result.SentenceCount {
+		t.Errorf("Estimate() returned %d sentences; want %d", len(result.Sentences), result.SentenceCount)
+	}
+	for _, s := range result.Sentences {
+		if text[s.Start:s.End] != s.Text {
+			t.Errorf("Sentence offsets [%d:%d] do not match its text %q", s.Start, s.End, s.Text)
+		}
+	}
+	if len(result.HardestSentences) != 2 {
+		t.Fatalf("Estimate() returned %d hardest sentences; want 2", len(result.HardestSentences))
+	}
+	if hardest := result.HardestSentences[0]; hardest.Paragraph != 2 || hardest.WordCount != 8 {
+		t.Errorf("Hardest sentence is %q from paragraph %d; want the long one from paragraph 2", hardest.Text, hardest.Paragraph)
+	}
+	if result.HardestSentences[0].FleschKincaidIndex > result.HardestSentences[1].FleschKincaidIndex {
+		t.Error("Hardest sentences must be sorted from the hardest")
+	}
+
+	plain, err := Estimate(text, Options{ReadingSpeed: 200, Workers: 2})
+	if err != nil {
+		t.Fatalf("Estimate() returned an unexpected error: %v", err)
+	}
+	if plain.Paragraphs != nil || plain.Sentences != nil || plain.HardestSentences != nil {
+		t.Error("Estimate() without Detailed and Hardest must not fill passage statistics")
+	}
+}
@@ -0,0 +1,33 @@
+package estimator
+
+// Языки, которые определяются по алфавиту слова
+const (
+	LanguageEnglish = "en"
+	LanguageRussian = "ru"
+)
+
+// Profile описывает особенности читателя
+type Profile struct {
+	LanguageSpeeds        map[string]float64 // Скорость чтения по языкам в словах в минуту, вместо Options.ReadingSpeed
+	DifficultySensitivity float64            // Доля, на которую замедляется чтение сложного текста
+	VisualsFactor         float64            // Во сколько раз иллюстрации увеличивают время чтения
+	SkimFactor            float64            // Доля времени чтения при беглом просмотре
+
+	// Уровень владения языками, для которых читатель не носитель. Чем больше в тексте редких
+	// для этого уровня слов, тем медленнее он читается; языки без уровня читаются как родные.
+	Proficiency map[string]CEFRLevel
+}
+
+// DefaultProfile возвращает профиль по умолчанию: сложный текст читается на 20% медленнее,
+// иллюстрации добавляют 10% времени, беглый просмотр занимает половину времени
+func DefaultProfile() Profile {
+	return Profile{DifficultySensitivity: 0.2, VisualsFactor: 1.1, SkimFactor: 0.5}
+}
+
+// profile возвращает профиль из opts или профиль по умолчанию
+func (opts Options) profile() Profile {
+	if opts.Profile == nil {
+		return DefaultProfile()
+	}
+	return *opts.Profile
+}
@@ -0,0 +1,240 @@
+package estimator
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Сокращения, после которых точка не заканчивает предложение, даже если дальше идет заглавная буква.
+// Сокращения вроде "etc." или "т.д." в списки не входят: перед заглавной буквой они обычно
+// действительно завершают предложение, а перед строчной точка и так не считается концом.
+var (
+	englishAbbreviations = newAbbreviationSet(
+		"mr", "mrs", "ms", "dr", "prof", "sr", "jr", "st", "mt", "ft", "rev", "hon", "gov", "sen", "rep",
+		"gen", "col", "capt", "lt", "sgt", "messrs", "e.g", "i.e", "cf", "vs", "viz", "approx", "dept",
+		"fig", "figs", "no", "nos", "vol", "vols", "ch", "sec", "p", "pp", "ed", "eds", "al", "ca",
+	)
+	russianAbbreviations = newAbbreviationSet(
+		"т.е", "т.к", "т.н", "т.ч", "напр", "см", "ср", "им", "ул", "пер", "пл", "просп", "д", "кв", "корп",
+		"стр", "рис", "табл", "гл", "разд", "п", "пп", "проф", "акад", "доц", "канд", "тов", "г-н", "г-жа",
+		"англ", "лат", "рус", "нем", "франц", "греч", "букв", "прим", "ред", "изд", "т", "тт", "ок",
+	)
+)
+
+func newAbbreviationSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, w := range words {
+		set[w] = true
+	}
+	return set
+}
+
+func isTerminator(r rune) bool {
+	return r == '.' || r == '!' || r == '?' || r == '…' || isCJKTerminator(r)
+}
+
+// isCJKTerminator сообщает, является ли символ полноширинным знаком конца предложения.
+// После таких знаков пробел не ставится, поэтому они всегда завершают предложение.
+func isCJKTerminator(r rune) bool {
+	return r == '。' || r == '！' || r == '？' || r == '｡'
+}
+
+// isClosing сообщает, может ли символ стоять после знака конца предложения (закрывающие кавычки и скобки)
+func isClosing(r rune) bool {
+	return strings.ContainsRune(`"')]}»”’」』）】`, r)
+}
+
+// isOpening сообщает, может ли символ стоять перед первым словом предложения
+func isOpening(r rune) bool {
+	return strings.ContainsRune(`"'([{«„“‘「『（【`, r)
+}
+
+func isDash(r rune) bool {
+	return r == '—' || r == '–' || r == '-'
+}
+
+// SplitSentences делит текст на предложения, сохраняя их положение в тексте.
+// Пустая строка всегда завершает предложение, даже если перед ней нет знака препинания.
+// Точки в сокращениях ("т.е.", "Dr."), инициалах, числах ("3.14"), адресах и номерах пунктов
+// списка концом предложения не считаются, как и знаки, после которых текст продолжается со строчной буквы.
+func SplitSentences(text string) []Span {
+	var spans []Span
+	start := 0
+	for _, loc := range paragraphBreakRegex.FindAllStringIndex(text, -1) {
+		spans = splitBlock(spans, text, start, loc[0])
+		start = loc[1]
+	}
+	return splitBlock(spans, text, start, len(text))
+}
+
+// splitBlock делит на предложения фрагмент text[start:end], не содержащий пустых строк
+func splitBlock(spans []Span, text string, start, end int) []Span {
+	sentenceStart := start
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(text[i:end])
+		if !isTerminator(r) {
+			i += size
+			continue
+		}
+
+		// Серия знаков конца предложения вместе с закрывающими кавычками и скобками
+		runEnd := i
+		for runEnd < end {
+			r, size := utf8.DecodeRuneInString(text[runEnd:end])
+			if !isTerminator(r) {
+				break
+			}
+			runEnd += size
+		}
+		run := text[i:runEnd]
+		for runEnd < end {
+			r, size := utf8.DecodeRuneInString(text[runEnd:end])
+			if !isClosing(r) {
+				break
+			}
+			runEnd += size
+		}
+
+		if isSentenceBoundary(text, start, i, runEnd, end, run) {
+			// Знаки препинания без текста перед ними (например, "..." после точки) предложением не считаем
+			if strings.TrimSpace(text[sentenceStart:i]) != "" {
+				spans = appendSpan(spans, text, sentenceStart, runEnd)
+			}
+			sentenceStart = runEnd
+		}
+		i = runEnd
+	}
+	return appendSpan(spans, text, sentenceStart, end)
+}
+
+// isSentenceBoundary решает, заканчивает ли серия знаков text[runStart:runEnd] предложение
+func isSentenceBoundary(text string, blockStart, runStart, runEnd, end int, run string) bool {
+	if runEnd == end || strings.IndexFunc(run, isCJKTerminator) >= 0 {
+		return true
+	}
+
+	// Знак внутри слова, числа или адреса: 3.14, example.com, т.е, ?q=1
+	if r, _ := utf8.DecodeRuneInString(text[runEnd:end]); !unicode.IsSpace(r) {
+		return false
+	}
+
+	next := nextWordRune(text, runEnd, end)
+	if next == utf8.RuneError {
+		return true
+	}
+	// Предложение продолжается со строчной буквы: "т.е. это", "ну... может", «Стой!» — крикнул он
+	if unicode.IsLower(next) {
+		return false
+	}
+
+	if run != "." {
+		return true
+	}
+
+	word := wordBefore(text, blockStart, runStart)
+	lower := strings.ToLower(word)
+	if isRussianWord(word) {
+		if russianAbbreviations[lower] {
+			return false
+		}
+	} else if englishAbbreviations[lower] {
+		return false
+	}
+
+	// Инициалы: "А. С. Пушкин", "J.R.R. Tolkien"
+	if isInitials(word) && unicode.IsUpper(next) {
+		return false
+	}
+
+	// Номер пункта списка в начале строки: "1. Откройте файл"
+	if isNumber(word) && isLineStart(text, blockStart, runStart-len(word)) {
+		return false
+	}
+
+	return true
+}
+
+// nextWordRune возвращает первую букву или цифру после позиции pos, пропуская пробелы,
+// открывающие кавычки и тире прямой речи. Если ничего не найдено, возвращает utf8.RuneError.
+func nextWordRune(text string, pos, end int) rune {
+	for pos < end {
+		r, size := utf8.DecodeRuneInString(text[pos:end])
+		if !unicode.IsSpace(r) && !isOpening(r) && !isDash(r) {
+			return r
+		}
+		pos += size
+	}
+	return utf8.RuneError
+}
+
+// wordBefore возвращает слово вместе с внутренними точками ("т.е", "e.g", "А.С"), стоящее перед позицией pos.
+// Сокращения, записанные через пробел ("т. е."), склеиваются без пробела.
+func wordBefore(text string, start, pos int) string {
+	var parts []string
+	for {
+		i := pos
+		for i > start {
+			r, size := utf8.DecodeLastRuneInString(text[start:i])
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '.' && r != '-' {
+				break
+			}
+			i -= size
+		}
+		part := strings.Trim(text[i:pos], ".")
+		if part == "" {
+			break
+		}
+		parts = append([]string{part}, parts...)
+
+		// "т. е." и "и т. д.": предыдущая часть — одна буква с точкой через пробел
+		if utf8.RuneCountInString(part) != 1 || i-2 < start || text[i-1] != ' ' || text[i-2] != '.' {
+			break
+		}
+		prev := i - 2
+		r, size := utf8.DecodeLastRuneInString(text[start:prev])
+		if !unicode.IsLetter(r) || (prev-size > start && !isWordBoundary(text, start, prev-size)) {
+			break
+		}
+		pos = prev
+	}
+	return strings.Join(parts, ".")
+}
+
+// isWordBoundary сообщает, стоит ли перед позицией pos символ, не являющийся буквой
+func isWordBoundary(text string, start, pos int) bool {
+	r, _ := utf8.DecodeLastRuneInString(text[start:pos])
+	return !unicode.IsLetter(r)
+}
+
+// isInitials сообщает, состоит ли слово из одиночных заглавных букв, разделенных точками
+func isInitials(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, part := range strings.Split(word, ".") {
+		runes := []rune(part)
+		if len(runes) != 1 || !unicode.IsUpper(runes[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumber(word string) bool {
+	if word == "" {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLineStart сообщает, что перед позицией pos в строке нет ничего, кроме пробелов
+func isLineStart(text string, start, pos int) bool {
+	lineStart := strings.LastIndexByte(text[start:pos], '\n') + 1
+	return strings.TrimSpace(text[start+lineStart:pos]) == ""
+}
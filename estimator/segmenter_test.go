@@ -0,0 +1,57 @@
+package estimator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSplitSentencesRules(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"Это, т.е. пример, работает. Дальше.", []string{"Это, т.е. пример, работает.", "Дальше."}},
+		{"Купили хлеб, молоко и т. д. Потом ушли.", []string{"Купили хлеб, молоко и т. д.", "Потом ушли."}},
+		{"Это, т. е. пример, тоже работает.", []string{"Это, т. е. пример, тоже работает."}},
+		{"Dr. Smith met Mr. Jones. They talked.", []string{"Dr. Smith met Mr. Jones.", "They talked."}},
+		{"Use a tool, e.g. Python. It helps.", []string{"Use a tool, e.g. Python.", "It helps."}},
+		{"Pi is 3.14 roughly. Ok.", []string{"Pi is 3.14 roughly.", "Ok."}},
+		{"Visit https://example.com/a.html?q=1 now. Thanks!", []string{"Visit https://example.com/a.html?q=1 now.", "Thanks!"}},
+		{"Write to info@example.org. Bye.", []string{"Write to info@example.org.", "Bye."}},
+		{"Стихи написал А. С. Пушкин. Их читают.", []string{"Стихи написал А. С. Пушкин.", "Их читают."}},
+		{"J.R.R. Tolkien wrote it. Yes.", []string{"J.R.R. Tolkien wrote it.", "Yes."}},
+		{"Он подумал... и ушёл. Потом вернулся.", []string{"Он подумал... и ушёл.", "Потом вернулся."}},
+		{"Она ждала… Никто не пришёл.", []string{"Она ждала…", "Никто не пришёл."}},
+		{"«Стой!» — крикнул он. Все замерли.", []string{"«Стой!» — крикнул он.", "Все замерли."}},
+		{`He said "Stop." Then he left.`, []string{`He said "Stop."`, "Then he left."}},
+		{"Заголовок без точки\n\nПервый абзац. Второе предложение", []string{"Заголовок без точки", "Первый абзац.", "Второе предложение"}},
+		{"Steps:\n1. Open the file.\n2. Save it.", []string{"Steps:\n1. Open the file.", "2. Save it."}},
+		{"См. рис. 5 на стр. 10. Готово.", []string{"См. рис. 5 на стр. 10.", "Готово."}},
+		{"我们学习中文。你呢？「好！」他说。", []string{"我们学习中文。", "你呢？", "「好！」", "他说。"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("text=%q", test.text), func(t *testing.T) {
+			spans := SplitSentences(test.text)
+			if len(spans) != len(test.expected) {
+				t.Fatalf("SplitSentences(%q) returned %d sentences %q; want %d", test.text, len(spans), spanTexts(spans), len(test.expected))
+			}
+			for i, span := range spans {
+				if span.Text != test.expected[i] {
+					t.Errorf("Sentence %d is %q; want %q", i, span.Text, test.expected[i])
+				}
+				if test.text[span.Start:span.End] != span.Text {
+					t.Errorf("Sentence %d offsets [%d:%d] do not match its text", i, span.Start, span.End)
+				}
+			}
+		})
+	}
+}
+
+func spanTexts(spans []Span) []string {
+	texts := make([]string, len(spans))
+	for i, span := range spans {
+		texts[i] = span.Text
+	}
+	return texts
+}
@@ -0,0 +1,114 @@
+package estimator
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/kljensen/snowball/english"
+	"github.com/kljensen/snowball/russian"
+)
+
+// Stemmer приводит слово к основе, чтобы разные формы слова считались одним словом
+type Stemmer interface {
+	Stem(word string) string
+}
+
+// StemmerFunc позволяет использовать функцию как Stemmer
+type StemmerFunc func(word string) string
+
+func (f StemmerFunc) Stem(word string) string {
+	return f(word)
+}
+
+// languageScript связывает алфавит с языком, на котором написаны слова этим алфавитом
+type languageScript struct {
+	language string
+	script   *unicode.RangeTable
+}
+
+var (
+	stemmersMu sync.RWMutex
+	// Стеммеры Snowball; служебные слова ("the", "было") не изменяются
+	stemmers = map[string]Stemmer{
+		LanguageEnglish: StemmerFunc(func(word string) string { return english.Stem(word, false) }),
+		LanguageRussian: StemmerFunc(func(word string) string { return russian.Stem(word, false) }),
+	}
+	// Алфавиты языков в порядке проверки: кириллица — русский, латиница — английский
+	scripts = []languageScript{
+		{LanguageRussian, unicode.Cyrillic},
+		{LanguageEnglish, unicode.Latin},
+	}
+)
+
+// RegisterScript задает язык слов, написанных алфавитом script, например unicode.Greek.
+// Алфавит проверяется раньше зарегистрированных до него, поэтому так можно и переназначить
+// латиницу или кириллицу другому языку. Вместе со стеммером (см. RegisterStemmer) это
+// добавляет язык в Stem, словарные показатели и остальные расчеты по языкам.
+func RegisterScript(language string, script *unicode.RangeTable) {
+	stemmersMu.Lock()
+	defer stemmersMu.Unlock()
+	scripts = slices.DeleteFunc(scripts, func(s languageScript) bool { return s.script == script })
+	scripts = slices.Insert(scripts, 0, languageScript{language, script})
+}
+
+// RegisterStemmer задает стеммер для языка, заменяя прежний
+func RegisterStemmer(language string, stemmer Stemmer) {
+	stemmersMu.Lock()
+	defer stemmersMu.Unlock()
+	stemmers[language] = stemmer
+}
+
+// StemmerFor возвращает стеммер языка
+func StemmerFor(language string) (Stemmer, bool) {
+	stemmersMu.RLock()
+	defer stemmersMu.RUnlock()
+	stemmer, ok := stemmers[language]
+	return stemmer, ok
+}
+
+// Stem возвращает основу слова. Язык определяется по алфавиту; слова языков без стеммера
+// только нормализуются (см. NormalizeWord).
+func Stem(word string) string {
+	return StemLanguage(word, wordLanguage(word))
+}
+
+// wordLanguage определяет язык слова по алфавиту первой буквы (см. RegisterScript).
+// Для слов без букв зарегистрированных алфавитов возвращается пустая строка.
+func wordLanguage(word string) string {
+	stemmersMu.RLock()
+	defer stemmersMu.RUnlock()
+	for _, r := range word {
+		for _, s := range scripts {
+			if unicode.Is(s.script, r) {
+				return s.language
+			}
+		}
+	}
+	return ""
+}
+
+// StemLanguage возвращает основу слова стеммером языка language
+func StemLanguage(word, language string) string {
+	word = NormalizeWord(word)
+	if stemmer, ok := StemmerFor(language); ok {
+		return stemmer.Stem(word)
+	}
+	return word
+}
+
+// NormalizeWord приводит слово к нижнему регистру, заменяет "ё" на "е" и типографский апостроф
+// на обычный, убирает знаки ударения и мягкие переносы
+func NormalizeWord(word string) string {
+	word = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\u00AD' || unicode.Is(unicode.Mn, r):
+			return -1
+		case r == '’':
+			return '\''
+		}
+		return r
+	}, strings.ToLower(word))
+	return strings.ReplaceAll(word, "ё", "е")
+}
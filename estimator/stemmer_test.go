@@ -0,0 +1,77 @@
+package estimator
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestStem(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{"Running", "run"},
+		{"projects", "project"},
+		{"don’t", "don't"},
+		{"the", "the"},
+		{"Проекту", "проект"},
+		{"проектами", "проект"},
+		{"Ёлками", "елк"},
+		{"было", "было"},
+		{"2024", "2024"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.word, func(t *testing.T) {
+			if got := Stem(test.word); got != test.want {
+				t.Errorf("Stem(%q) = %q, want %q", test.word, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegisterStemmer(t *testing.T) {
+	RegisterStemmer("xx", StemmerFunc(func(word string) string { return strings.TrimSuffix(word, "ex") }))
+	// Реестр общий для всех тестов пакета, поэтому тестовый стеммер удаляется
+	t.Cleanup(func() {
+		stemmersMu.Lock()
+		defer stemmersMu.Unlock()
+		delete(stemmers, "xx")
+	})
+	if got := StemLanguage("Codex", "xx"); got != "cod" {
+		t.Errorf("StemLanguage with a registered stemmer = %q, want %q", got, "cod")
+	}
+	if got := StemLanguage("Codex", "yy"); got != "codex" {
+		t.Errorf("StemLanguage without a stemmer = %q, want %q", got, "codex")
+	}
+}
+
+func TestRegisterScript(t *testing.T) {
+	RegisterScript("el", unicode.Greek)
+	RegisterStemmer("el", StemmerFunc(func(word string) string {
+		return strings.TrimSuffix(strings.TrimSuffix(word, "ες"), "α")
+	}))
+	t.Cleanup(func() {
+		stemmersMu.Lock()
+		defer stemmersMu.Unlock()
+		delete(stemmers, "el")
+		scripts = slices.DeleteFunc(scripts, func(s languageScript) bool { return s.language == "el" })
+	})
+
+	if got := Stem("Γάτες"); got != "γάτ" {
+		t.Errorf("Stem(Γάτες) = %q, want %q", got, "γάτ")
+	}
+	if got := Stem("cats"); got != "cat" {
+		t.Errorf("Stem(cats) = %q, want %q", got, "cat")
+	}
+
+	stats := AnalyzeVocabulary(Tokenize("Γάτα, γάτες και γάτα. The cats."), nil)
+	if len(stats) != 2 || stats[0].Language != "el" {
+		t.Fatalf("AnalyzeVocabulary() = %+v; want el and en", stats)
+	}
+	if greek := stats[0]; greek.Tokens != 4 || greek.Types != 2 || greek.TopLemmas[0] != (LemmaCount{Lemma: "γάτα", Count: 3}) {
+		t.Errorf("AnalyzeVocabulary() for el = %+v; want 4 words, 2 stems and γάτα 3 times", greek)
+	}
+}
@@ -0,0 +1,154 @@
+package estimator
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// SyllableBackend определяет способ подсчета слогов
+type SyllableBackend string
+
+const (
+	// SyllablesHeuristic считает группы гласных (поведение по умолчанию)
+	SyllablesHeuristic SyllableBackend = "heuristic"
+	// SyllablesPatterns использует словарь исключений и шаблоны переносов TeX
+	SyllablesPatterns SyllableBackend = "patterns"
+)
+
+var (
+	//go:embed data/hyph-en-us.pat.txt
+	englishPatterns string
+	//go:embed data/hyph-ru.pat.txt
+	russianPatterns string
+	//go:embed data/syllables-en.txt
+	englishSyllableDictionary string
+
+	syllableDataOnce     sync.Once
+	englishHyphenator    *Hyphenator
+	russianHyphenator    *Hyphenator
+	englishSyllableWords map[string]int
+
+	englishLettersRegex = regexp.MustCompile(`^[a-z]+$`)
+	russianLettersRegex = regexp.MustCompile(`^[а-яё]+$`)
+	silentEdRegex       = regexp.MustCompile(`[^aeiouytd]ed$`)
+	silentEsRegex       = regexp.MustCompile(`[^aeiouysxzcg]es$`)
+	sibilantEsRegex     = regexp.MustCompile(`[cs]hes$`)
+	consonantLeRegex    = regexp.MustCompile(`[^aeiouy]les?$`)
+)
+
+// ParseSyllableBackend разбирает название способа подсчета слогов
+func ParseSyllableBackend(name string) (SyllableBackend, error) {
+	switch backend := SyllableBackend(strings.ToLower(strings.TrimSpace(name))); backend {
+	case SyllablesHeuristic, SyllablesPatterns:
+		return backend, nil
+	case "":
+		return SyllablesHeuristic, nil
+	default:
+		return "", fmt.Errorf("%w %q (supported: %s, %s)", ErrUnknownSyllableBackend, name, SyllablesHeuristic, SyllablesPatterns)
+	}
+}
+
+// CountSyllablesWith считает слоги в слове выбранным способом
+func CountSyllablesWith(word string, backend SyllableBackend) int {
+	if backend == SyllablesPatterns {
+		return countSyllablesPatterns(word)
+	}
+	return CountSyllables(word)
+}
+
+func loadSyllableData() {
+	// Минимальная длина частей 2 не дает шаблонам отделять одиночные согласные ("s-mile")
+	englishHyphenator = NewHyphenator(englishPatterns, 2, 2)
+	russianHyphenator = NewHyphenator(russianPatterns, 1, 1)
+
+	englishSyllableWords = make(map[string]int)
+	scanner := bufio.NewScanner(strings.NewReader(englishSyllableDictionary))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "%") {
+			continue
+		}
+		if count, err := strconv.Atoi(fields[1]); err == nil {
+			englishSyllableWords[fields[0]] = count
+		}
+	}
+}
+
+// countSyllablesPatterns считает слоги по словарю и шаблонам переносов.
+// Слова, которые шаблоны не покрывают (с цифрами, смешанными алфавитами и т.п.), считаются эвристикой.
+func countSyllablesPatterns(word string) int {
+	syllableDataOnce.Do(loadSyllableData)
+
+	word = strings.ToLower(word)
+	switch {
+	case englishLettersRegex.MatchString(word):
+		if count, ok := englishSyllableWords[word]; ok {
+			return count
+		}
+		parts := englishHyphenator.Hyphenate(word)
+		count := 0
+		for i, part := range parts {
+			count += englishPartSyllables(part, i == len(parts)-1)
+		}
+		return max(count, 1)
+
+	case russianLettersRegex.MatchString(word):
+		count := 0
+		for _, part := range russianHyphenator.Hyphenate(strings.ReplaceAll(word, "ё", "е")) {
+			count += russianPartSyllables(part)
+		}
+		return max(count, 1)
+	}
+
+	return CountSyllables(word)
+}
+
+// russianPartSyllables считает слоги в части русского слова. Обычно шаблоны отделяют ровно один слог,
+// но не разделяют стечения гласных ("ра-дио") и иногда отделяют одни согласные ("в-стре-ча"),
+// поэтому часть без гласных слогом не считается, а в части с несколькими гласными слогом считается каждая гласная.
+func russianPartSyllables(part string) int {
+	vowels := 0
+	for _, r := range part {
+		if strings.ContainsRune(russianVowels, r) {
+			vowels++
+		}
+	}
+	return vowels
+}
+
+// englishPartSyllables считает группы гласных в части английского слова.
+// В последней части учитываются немые окончания: "-e", "-ed", "-es" и слоговое "-le".
+func englishPartSyllables(part string, last bool) int {
+	if last {
+		switch {
+		case consonantLeRegex.MatchString(part):
+			part = strings.TrimSuffix(strings.TrimSuffix(part, "s"), "le") + "al"
+		case silentEdRegex.MatchString(part), silentEsRegex.MatchString(part) && !sibilantEsRegex.MatchString(part):
+			part = part[:len(part)-2]
+		case strings.HasSuffix(part, "e") && !strings.HasSuffix(part, "ee") && !strings.HasSuffix(part, "ye") &&
+			!strings.HasSuffix(part, "oe") && englishVowelGroups(part) > 1:
+			part = part[:len(part)-1]
+		}
+	}
+	return englishVowelGroups(part)
+}
+
+// englishVowelGroups считает группы гласных; начальная "y" считается согласной
+func englishVowelGroups(part string) int {
+	part = strings.TrimPrefix(part, "y")
+	groups := 0
+	lastWasVowel := false
+	for _, r := range part {
+		isVowel := strings.ContainsRune(englishVowels, r)
+		if isVowel && !lastWasVowel {
+			groups++
+		}
+		lastWasVowel = isVowel
+	}
+	return groups
+}
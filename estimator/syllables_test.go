@@ -0,0 +1,145 @@
+package estimator
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// loadLabeledWords читает размеченный список слов в формате "слово количество_слогов"
+func loadLabeledWords(t *testing.T, path string) map[string]int {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer file.Close()
+
+	words := make(map[string]int)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			t.Fatalf("invalid syllable count in %s: %q", path, scanner.Text())
+		}
+		words[fields[0]] = count
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+	return words
+}
+
+// syllableAccuracy возвращает долю слов, для которых способ подсчета дает правильное количество слогов
+func syllableAccuracy(words map[string]int, backend SyllableBackend) (float64, []string) {
+	var misses []string
+	for word, expected := range words {
+		if got := CountSyllablesWith(word, backend); got != expected {
+			misses = append(misses, fmt.Sprintf("%s=%d(want %d)", word, got, expected))
+		}
+	}
+	return 1 - float64(len(misses))/float64(len(words)), misses
+}
+
+func TestSyllablePatternsAccuracy(t *testing.T) {
+	tests := []struct {
+		path        string
+		minAccuracy float64
+	}{
+		{"testdata/syllables-en.txt", 1},
+		// Слова, которые не использовались при составлении словаря исключений
+		{"testdata/syllables-en-heldout.txt", 0.96},
+		{"testdata/syllables-ru.txt", 1},
+	}
+
+	// Слова из словаря исключений считаются по словарю, поэтому точность шаблонов
+	// измеряется только на остальных словах
+	syllableDataOnce.Do(loadSyllableData)
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			words := loadLabeledWords(t, test.path)
+			for word := range words {
+				if _, ok := englishSyllableWords[word]; ok {
+					delete(words, word)
+				}
+			}
+
+			patterns, misses := syllableAccuracy(words, SyllablesPatterns)
+			heuristic, _ := syllableAccuracy(words, SyllablesHeuristic)
+			t.Logf("accuracy: patterns %.3f, heuristic %.3f", patterns, heuristic)
+
+			if patterns < test.minAccuracy {
+				t.Errorf("Pattern backend accuracy %.3f is below %.3f; misses: %v", patterns, test.minAccuracy, misses)
+			}
+			if patterns < heuristic {
+				t.Errorf("Pattern backend accuracy %.3f is worse than heuristic %.3f", patterns, heuristic)
+			}
+		})
+	}
+}
+
+func TestCountSyllablesWithPatterns(t *testing.T) {
+	tests := []struct {
+		word     string
+		expected int
+	}{
+		{"rhythm", 2},
+		{"queue", 1},
+		{"created", 3},
+		{"walked", 1},
+		{"tables", 2},
+		{"Hyphenation", 4},
+		{"ёлка", 2},
+		{"преимущество", 5},
+		{"здравствуйте", 3},
+		{"COVID19", CountSyllables("covid19")},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("word=%s", test.word), func(t *testing.T) {
+			if result := CountSyllablesWith(test.word, SyllablesPatterns); result != test.expected {
+				t.Errorf("CountSyllablesWith(%s, patterns) = %d; want %d", test.word, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestHyphenate(t *testing.T) {
+	h := NewHyphenator("% comment\n1ba\nhy3ph\nhe2n\nhena4\nhen5at\n1na\nn2at\n1tio\n2io\no2n", 2, 3)
+
+	parts := h.Hyphenate("hyphenation")
+	if got := strings.Join(parts, "-"); got != "hy-phen-ation" {
+		t.Errorf("Hyphenate(hyphenation) = %s; want hy-phen-ation", got)
+	}
+	if parts := h.Hyphenate("hen"); len(parts) != 1 {
+		t.Errorf("Hyphenate() must not break words shorter than leftMin+rightMin, got %v", parts)
+	}
+
+	// Русские шаблоны делят слово по слогам, на них основан подсчет слогов
+	syllableDataOnce.Do(loadSyllableData)
+	for word, want := range map[string]string{"собака": "со-ба-ка", "преимущество": "пре-иму-ще-ство", "радио": "ра-дио"} {
+		if got := strings.Join(russianHyphenator.Hyphenate(word), "-"); got != want {
+			t.Errorf("Hyphenate(%s) = %s; want %s", word, got, want)
+		}
+	}
+}
+
+func TestParseSyllableBackend(t *testing.T) {
+	for name, expected := range map[string]SyllableBackend{"": SyllablesHeuristic, "Patterns": SyllablesPatterns, "heuristic": SyllablesHeuristic} {
+		if backend, err := ParseSyllableBackend(name); err != nil || backend != expected {
+			t.Errorf("ParseSyllableBackend(%q) = %q, %v; want %q", name, backend, err, expected)
+		}
+	}
+	if _, err := ParseSyllableBackend("cmudict"); err == nil {
+		t.Error("ParseSyllableBackend() expected an error for an unknown backend")
+	}
+}
@@ -0,0 +1,301 @@
+package estimator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Средняя скорость чтения взрослых носителей языка в символах в минуту
+// (Trauzettel-Klosinski et al., 2012)
+const (
+	ChineseCharactersPerMinute  = 255
+	JapaneseCharactersPerMinute = 357
+)
+
+// TokenClass определяет вид токена текста
+type TokenClass string
+
+const (
+	TokenWord         TokenClass = "word"
+	TokenNumber       TokenClass = "number"
+	TokenURL          TokenClass = "url"
+	TokenEmail        TokenClass = "email"
+	TokenIdentifier   TokenClass = "identifier"
+	TokenAbbreviation TokenClass = "abbreviation"
+	TokenEmoji        TokenClass = "emoji"
+	TokenCJK          TokenClass = "cjk" // Слова на китайском или японском
+)
+
+// TokenClasses возвращает все виды токенов в порядке вывода
+func TokenClasses() []TokenClass {
+	return []TokenClass{TokenWord, TokenNumber, TokenURL, TokenEmail, TokenIdentifier, TokenAbbreviation, TokenEmoji, TokenCJK}
+}
+
+// ParseTokenClass разбирает название вида токена
+func ParseTokenClass(name string) (TokenClass, error) {
+	class := TokenClass(strings.ToLower(strings.TrimSpace(name)))
+	for _, c := range TokenClasses() {
+		if c == class {
+			return class, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownTokenClass, name)
+}
+
+// SyllablePolicy определяет, как слоги токена учитываются в индексе читаемости
+type SyllablePolicy string
+
+const (
+	// SyllablePolicyCount считает слоги как у обычного слова
+	SyllablePolicyCount SyllablePolicy = "count"
+	// SyllablePolicySpell считает по слогу на каждую букву или цифру: "HTML" читается по буквам
+	SyllablePolicySpell SyllablePolicy = "spell"
+	// SyllablePolicySkip исключает токен из расчета индекса читаемости
+	SyllablePolicySkip SyllablePolicy = "skip"
+)
+
+// ParseSyllablePolicy разбирает название правила подсчета слогов
+func ParseSyllablePolicy(name string) (SyllablePolicy, error) {
+	switch policy := SyllablePolicy(strings.ToLower(strings.TrimSpace(name))); policy {
+	case SyllablePolicyCount, SyllablePolicySpell, SyllablePolicySkip:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w %q (supported: %s, %s, %s)", ErrUnknownSyllablePolicy, name, SyllablePolicyCount, SyllablePolicySpell, SyllablePolicySkip)
+	}
+}
+
+// ClassPolicy задает, как вид токена влияет на время чтения и индекс читаемости
+type ClassPolicy struct {
+	Cost      float64        // Время чтения токена в обычных словах
+	Syllables SyllablePolicy // Учет слогов в индексе читаемости
+}
+
+// DefaultClassPolicies возвращает правила по умолчанию: адреса и идентификаторы читаются дольше
+// обычного слова, но в индекс читаемости не входят, эмодзи почти не занимают времени.
+// Для cjk стоимость задается на один символ в единицах скорости Options.CharactersPerMinute,
+// а индекс Флеша-Кинкейда к китайскому и японскому тексту неприменим.
+func DefaultClassPolicies() map[TokenClass]ClassPolicy {
+	return map[TokenClass]ClassPolicy{
+		TokenWord:         {Cost: 1, Syllables: SyllablePolicyCount},
+		TokenNumber:       {Cost: 1, Syllables: SyllablePolicySkip},
+		TokenURL:          {Cost: 2, Syllables: SyllablePolicySkip},
+		TokenEmail:        {Cost: 1.5, Syllables: SyllablePolicySkip},
+		TokenIdentifier:   {Cost: 1.5, Syllables: SyllablePolicySkip},
+		TokenAbbreviation: {Cost: 1, Syllables: SyllablePolicySpell},
+		TokenEmoji:        {Cost: 0.2, Syllables: SyllablePolicySkip},
+		TokenCJK:          {Cost: 1, Syllables: SyllablePolicySkip},
+	}
+}
+
+// Token — фрагмент текста с его видом и положением в тексте
+type Token struct {
+	Text  string
+	Class TokenClass
+	Start int
+	End   int
+}
+
+var (
+	// Адреса и email ищутся до деления на слова: правила UAX #29 разбили бы их на части
+	addressRegex = regexp.MustCompile(`(?P<url>(?i:https?://|ftp://|www\.)[^\s<>"'«»()\[\]{}]+)|(?P<email>[\p{L}\p{N}._%+-]+@[\p{L}\p{N}-]+(?:\.[\p{L}\p{N}-]+)+)`)
+
+	// Домены верхнего уровня, по которым адрес без схемы ("example.com") отличается от идентификатора ("os.Args")
+	topLevelDomains = newAbbreviationSet("com", "org", "net", "edu", "gov", "io", "dev", "app", "info", "ru", "рф", "su", "uk", "de", "fr", "eu", "us")
+)
+
+// Tokenize делит текст на токены и определяет вид каждого
+func Tokenize(text string) []Token {
+	var tokens []Token
+	pos := 0
+	for _, loc := range addressRegex.FindAllStringSubmatchIndex(text, -1) {
+		tokens = appendWordTokens(tokens, text, pos, loc[0])
+
+		class, start, end := TokenURL, loc[2], loc[3]
+		if start < 0 {
+			class, start, end = TokenEmail, loc[4], loc[5]
+		} else {
+			// Знаки препинания в конце адреса относятся к предложению
+			end = start + len(strings.TrimRight(text[start:end], ".,;:!?"))
+		}
+		tokens = append(tokens, Token{Text: text[start:end], Class: class, Start: start, End: end})
+		pos = end
+	}
+	return appendWordTokens(tokens, text, pos, len(text))
+}
+
+// appendWordTokens добавляет токены из слов фрагмента text[start:end]
+func appendWordTokens(tokens []Token, text string, start, end int) []Token {
+	for _, word := range SegmentWords(text[start:end]) {
+		tokens = append(tokens, Token{
+			Text:  word.Text,
+			Class: classifyWord(word.Text),
+			Start: start + word.Start,
+			End:   start + word.End,
+		})
+	}
+	return tokens
+}
+
+// classifyWord определяет вид слова, выделенного SegmentWords
+func classifyWord(word string) TokenClass {
+	if isCJKSegment(word) {
+		return TokenCJK
+	}
+	if emojiRegex.MatchString(word) {
+		return TokenEmoji
+	}
+	if isNumeric(strings.TrimSuffix(word, "%")) {
+		return TokenNumber
+	}
+	if isDottedAbbreviation(strings.TrimSuffix(word, ".")) {
+		return TokenAbbreviation
+	}
+	if i := strings.LastIndexByte(word, '.'); i >= 0 {
+		if topLevelDomains[strings.ToLower(word[i+1:])] {
+			return TokenURL
+		}
+		return TokenIdentifier
+	}
+
+	letters, upper := 0, 0
+	prevLower := false
+	camelCase := false
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+				camelCase = camelCase || prevLower
+			}
+		}
+		prevLower = unicode.IsLower(r)
+	}
+
+	switch {
+	case strings.Contains(word, "_") || camelCase:
+		return TokenIdentifier
+	case letters >= 2 && letters <= 5 && upper == letters && !strings.ContainsAny(word, "-‐‑'’"):
+		// Короткое слово из заглавных букв: "NASA", "HTML", "СССР"
+		return TokenAbbreviation
+	}
+	return TokenWord
+}
+
+// tokenStats — показатели токенов фрагмента текста
+type tokenStats struct {
+	wordCount     int                // Все токены, кроме эмодзи
+	readable      []string           // Токены, слоги которых считаются как у слов
+	spelledWords  int                // Токены, читаемые по буквам
+	spelledLength int                // Буквы и цифры токенов, читаемых по буквам
+	costs         map[string]float64 // Время чтения в обычных словах по языкам
+	skippedCost   float64            // Время чтения токенов, слоги которых не считаются (числа, адреса и т.п.)
+	cjkCost       float64            // Время чтения китайского и японского текста в символах
+	japanese      bool               // Среди символов есть кана
+	counts        map[TokenClass]int
+	tokens        []Token
+}
+
+// measureTokens подсчитывает показатели токенов по правилам из policies
+func measureTokens(tokens []Token, policies map[TokenClass]ClassPolicy) tokenStats {
+	stats := tokenStats{costs: make(map[string]float64), counts: make(map[TokenClass]int), tokens: tokens}
+	for _, token := range tokens {
+		stats.counts[token.Class]++
+		if token.Class != TokenEmoji {
+			stats.wordCount++
+		}
+
+		policy := policies[token.Class]
+		if token.Class == TokenCJK {
+			characters := utf8.RuneCountInString(token.Text)
+			stats.cjkCost += policy.Cost * float64(characters)
+			for _, r := range token.Text {
+				if script := scriptOf(r); script == scriptHiragana || script == scriptKatakana {
+					stats.japanese = true
+				}
+			}
+		} else {
+			stats.costs[wordLanguage(token.Text)] += policy.Cost
+			if policy.Syllables == SyllablePolicySkip {
+				stats.skippedCost += policy.Cost
+			}
+		}
+		switch policy.Syllables {
+		case SyllablePolicyCount:
+			stats.readable = append(stats.readable, token.Text)
+		case SyllablePolicySpell:
+			stats.spelledWords++
+			for _, r := range token.Text {
+				if unicode.IsLetter(r) || unicode.IsDigit(r) {
+					stats.spelledLength++
+				}
+			}
+		}
+	}
+	return stats
+}
+
+// readableCount возвращает количество токенов, входящих в индекс читаемости
+func (s tokenStats) readableCount() int {
+	return len(s.readable) + s.spelledWords
+}
+
+// minutes возвращает время чтения токенов в минутах. Слова читаются со скоростью для своего языка
+// из профиля или со скоростью opts.ReadingSpeed, числа и адреса — со скоростью основного языка текста.
+// Если к тексту применим индекс читаемости fkIndex, сложный текст читается медленнее; слова языков,
+// для которых в профиле задан уровень владения, читаются медленнее в зависимости от доли редких слов.
+// Китайский и японский текст читается со скоростью opts.CharactersPerMinute или скоростью по умолчанию для языка.
+func (s tokenStats) minutes(opts Options, fkIndex float64) float64 {
+	profile := opts.profile()
+
+	// Основной язык — тот, на чтение слов которого уходит больше всего времени
+	main := ""
+	for language, cost := range s.costs {
+		if language != "" && (main == "" || cost > s.costs[main] || (cost == s.costs[main] && language < main)) {
+			main = language
+		}
+	}
+
+	learner := learnerFactors(s.tokens, profile.Proficiency)
+	minutes := 0.0
+	for language, cost := range s.costs {
+		// Числа и адреса читаются со скоростью основного языка, но незнакомыми словами не считаются
+		if factor, ok := learner[language]; ok {
+			cost *= factor
+		}
+		if language == "" {
+			language = main
+		}
+		speed := opts.ReadingSpeed
+		if languageSpeed, ok := profile.LanguageSpeeds[language]; ok {
+			speed = languageSpeed
+		}
+		if s.readableCount() > 0 {
+			speed = adjustSpeed(speed, fkIndex, profile.DifficultySensitivity)
+		}
+		minutes += cost / speed
+	}
+
+	if s.cjkCost > 0 {
+		charactersPerMinute := opts.CharactersPerMinute
+		if charactersPerMinute <= 0 {
+			charactersPerMinute = ChineseCharactersPerMinute
+			if s.japanese {
+				charactersPerMinute = JapaneseCharactersPerMinute
+			}
+		}
+		minutes += s.cjkCost / charactersPerMinute
+	}
+	return minutes
+}
+
+// classPolicies возвращает правила по умолчанию, дополненные правилами из opts
+func (opts Options) classPolicies() map[TokenClass]ClassPolicy {
+	policies := DefaultClassPolicies()
+	for class, policy := range opts.ClassPolicies {
+		policies[class] = policy
+	}
+	return policies
+}
@@ -0,0 +1,68 @@
+package estimator
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []Token
+	}{
+		{"Visit https://example.com/a.html?q=1.", []Token{{Text: "Visit", Class: TokenWord}, {Text: "https://example.com/a.html?q=1", Class: TokenURL}}},
+		{"Пишите на info@example.org сегодня", []Token{{Text: "Пишите", Class: TokenWord}, {Text: "на", Class: TokenWord}, {Text: "info@example.org", Class: TokenEmail}, {Text: "сегодня", Class: TokenWord}}},
+		{"In 2024 prices rose 3.5% to 1,200", []Token{{Text: "In", Class: TokenWord}, {Text: "2024", Class: TokenNumber}, {Text: "prices", Class: TokenWord}, {Text: "rose", Class: TokenWord}, {Text: "3.5%", Class: TokenNumber}, {Text: "to", Class: TokenWord}, {Text: "1,200", Class: TokenNumber}}},
+		{"call snake_case_identifier or getValue", []Token{{Text: "call", Class: TokenWord}, {Text: "snake_case_identifier", Class: TokenIdentifier}, {Text: "or", Class: TokenWord}, {Text: "getValue", Class: TokenIdentifier}}},
+		{"NASA и СССР, т.е. e.g. COVID-19", []Token{{Text: "NASA", Class: TokenAbbreviation}, {Text: "и", Class: TokenWord}, {Text: "СССР", Class: TokenAbbreviation}, {Text: "т.е.", Class: TokenAbbreviation}, {Text: "e.g.", Class: TokenAbbreviation}, {Text: "COVID-19", Class: TokenWord}}},
+		{"Great 👍🏽 job 👨‍👩‍👧 🇷🇺", []Token{{Text: "Great", Class: TokenWord}, {Text: "👍🏽", Class: TokenEmoji}, {Text: "job", Class: TokenWord}, {Text: "👨‍👩‍👧", Class: TokenEmoji}, {Text: "🇷🇺", Class: TokenEmoji}}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("text=%q", test.text), func(t *testing.T) {
+			tokens := Tokenize(test.text)
+			if len(tokens) != len(test.expected) {
+				t.Fatalf("Tokenize(%q) returned %d tokens %v; want %d", test.text, len(tokens), tokens, len(test.expected))
+			}
+			for i, token := range tokens {
+				if token.Text != test.expected[i].Text || token.Class != test.expected[i].Class {
+					t.Errorf("Token %d is %q (%s); want %q (%s)", i, token.Text, token.Class, test.expected[i].Text, test.expected[i].Class)
+				}
+				if test.text[token.Start:token.End] != token.Text {
+					t.Errorf("Token %d offsets [%d:%d] do not match its text", i, token.Start, token.End)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateTokenClasses(t *testing.T) {
+	text := "See https://example.com/docs for details. Version 2024 added getValue and NASA data."
+
+	result, err := Estimate(text, Options{ReadingSpeed: 200, Workers: 2})
+	if err != nil {
+		t.Fatalf("Estimate() returned error: %v", err)
+	}
+
+	expected := map[TokenClass]int{TokenWord: 7, TokenURL: 1, TokenNumber: 1, TokenIdentifier: 1, TokenAbbreviation: 1}
+	for class, count := range expected {
+		if result.TokenCounts[class] != count {
+			t.Errorf("TokenCounts[%s] = %d; want %d", class, result.TokenCounts[class], count)
+		}
+	}
+	if result.WordCount != 11 {
+		t.Errorf("WordCount = %d; want 11", result.WordCount)
+	}
+
+	// Адрес стоит двух слов, идентификатор — полутора
+	cheap, err := Estimate(text, Options{ReadingSpeed: 200, Workers: 2, ClassPolicies: map[TokenClass]ClassPolicy{
+		TokenURL:        {Cost: 1, Syllables: SyllablePolicySkip},
+		TokenIdentifier: {Cost: 1, Syllables: SyllablePolicySkip},
+	}})
+	if err != nil {
+		t.Fatalf("Estimate() returned error: %v", err)
+	}
+	if cheap.ReadingTime >= result.ReadingTime {
+		t.Errorf("Cheaper class policies gave reading time %.2f; want less than %.2f", cheap.ReadingTime, result.ReadingTime)
+	}
+}
@@ -0,0 +1,205 @@
+package estimator
+
+import (
+	"bufio"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Порог доли уникальных слов, на котором MTLD завершает отрезок текста (McCarthy, Jarvis, 2010)
+const mtldThreshold = 0.72
+
+// TopLemmaCount — сколько самых частых лемм возвращается для каждого языка
+const TopLemmaCount = 10
+
+// LemmaCount — слово и число употреблений всех его форм. Формы объединяются по основе,
+// а словом считается самая частая из них.
+type LemmaCount struct {
+	Lemma string `json:"lemma" yaml:"lemma" toml:"lemma"`
+	Count int    `json:"count" yaml:"count" toml:"count"`
+}
+
+// VocabularyStats — показатели словарного разнообразия слов одного языка
+type VocabularyStats struct {
+	Language          string       `json:"language" yaml:"language" toml:"language"`
+	Tokens            int          `json:"tokens" yaml:"tokens" toml:"tokens"`                                        // Употребления слов
+	Types             int          `json:"types" yaml:"types" toml:"types"`                                           // Различные основы слов
+	TypeTokenRatio    float64      `json:"type_token_ratio" yaml:"type_token_ratio" toml:"type_token_ratio"`          // Types / Tokens
+	MTLD              float64      `json:"mtld" yaml:"mtld" toml:"mtld"`                                              // Measure of Textual Lexical Diversity
+	HapaxLegomena     int          `json:"hapax_legomena" yaml:"hapax_legomena" toml:"hapax_legomena"`                // Основы, встретившиеся один раз
+	AverageWordLength float64      `json:"average_word_length" yaml:"average_word_length" toml:"average_word_length"` // В буквах
+	TopLemmas         []LemmaCount `json:"top_lemmas" yaml:"top_lemmas" toml:"top_lemmas"`
+
+	// Доля употреблений слов не из частотного списка в процентах;
+	// считается, только если список для языка задан (FrequencyListSize > 0)
+	FrequencyListSize    int     `json:"frequency_list_size" yaml:"frequency_list_size" toml:"frequency_list_size"`
+	OutsideFrequencyList float64 `json:"outside_frequency_list" yaml:"outside_frequency_list" toml:"outside_frequency_list"`
+}
+
+// AnalyzeVocabulary рассчитывает показатели словарного разнообразия по языкам. Учитываются только
+// токены-слова, язык определяется по алфавиту, формы слова объединяются по основе (см. Stem).
+// lists — частотные списки слов по языкам. Языки упорядочены по числу слов.
+func AnalyzeVocabulary(tokens []Token, lists map[string][]string) []VocabularyStats {
+	stems := make(map[string][]string)
+	forms := make(map[string]map[string]map[string]int) // Формы каждой основы по языкам
+	letters := make(map[string]int)
+	for _, token := range tokens {
+		if token.Class != TokenWord {
+			continue
+		}
+		language := wordLanguage(token.Text)
+		if language == "" {
+			continue
+		}
+		stem := StemLanguage(token.Text, language)
+		stems[language] = append(stems[language], stem)
+		if forms[language] == nil {
+			forms[language] = make(map[string]map[string]int)
+		}
+		if forms[language][stem] == nil {
+			forms[language][stem] = make(map[string]int)
+		}
+		forms[language][stem][NormalizeWord(token.Text)]++
+		letters[language] += countLetters(token.Text)
+	}
+
+	var stats []VocabularyStats
+	for language, words := range stems {
+		counts := make(map[string]int)
+		for _, word := range words {
+			counts[word]++
+		}
+		s := VocabularyStats{
+			Language:          language,
+			Tokens:            len(words),
+			Types:             len(counts),
+			TypeTokenRatio:    float64(len(counts)) / float64(len(words)),
+			MTLD:              mtld(words),
+			AverageWordLength: float64(letters[language]) / float64(len(words)),
+			TopLemmas:         topLemmas(counts, forms[language], TopLemmaCount),
+		}
+		for _, count := range counts {
+			if count == 1 {
+				s.HapaxLegomena++
+			}
+		}
+
+		if list := frequencySet(lists[language], language); len(list) > 0 {
+			outside := 0
+			for _, word := range words {
+				if !list[word] {
+					outside++
+				}
+			}
+			s.FrequencyListSize = len(lists[language])
+			s.OutsideFrequencyList = 100 * float64(outside) / float64(len(words))
+		}
+		stats = append(stats, s)
+	}
+
+	slices.SortFunc(stats, func(a, b VocabularyStats) int {
+		if a.Tokens != b.Tokens {
+			return b.Tokens - a.Tokens
+		}
+		return strings.Compare(a.Language, b.Language)
+	})
+	return stats
+}
+
+// ReadFrequencyList читает частотный список: по слову в строке в порядке убывания частоты.
+// Остальные поля строки (например, число употреблений) и строки, начинающиеся с # или %, пропускаются.
+// size ограничивает список первыми size словами, 0 — без ограничения.
+func ReadFrequencyList(r io.Reader, size int) ([]string, error) {
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() && (size == 0 || len(words) < size) {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "%") {
+			continue
+		}
+		words = append(words, fields[0])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return words, nil
+}
+
+func countLetters(word string) int {
+	letters := 0
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+	return letters
+}
+
+// mtld рассчитывает MTLD как среднее прямого и обратного прохода по тексту
+func mtld(words []string) float64 {
+	reversed := slices.Clone(words)
+	slices.Reverse(reversed)
+	return (mtldPass(words) + mtldPass(reversed)) / 2
+}
+
+// mtldPass делит текст на отрезки, в которых доля уникальных слов не опускается до порога,
+// и возвращает среднюю длину отрезка; незавершенный остаток учитывается частично
+func mtldPass(words []string) float64 {
+	factors := 0.0
+	types := make(map[string]bool)
+	count := 0
+	ratio := 1.0
+	for _, word := range words {
+		types[word] = true
+		count++
+		ratio = float64(len(types)) / float64(count)
+		if ratio <= mtldThreshold {
+			factors++
+			clear(types)
+			count, ratio = 0, 1
+		}
+	}
+	if count > 0 {
+		factors += (1 - ratio) / (1 - mtldThreshold)
+	}
+	if factors == 0 {
+		// Все слова разные: текст короче одного отрезка
+		return float64(len(words))
+	}
+	return float64(len(words)) / factors
+}
+
+// topLemmas возвращает n самых частых основ, называя каждую самой частой формой
+func topLemmas(counts map[string]int, forms map[string]map[string]int, n int) []LemmaCount {
+	top := make([]LemmaCount, 0, len(counts))
+	for stem, count := range counts {
+		word := ""
+		for form, formCount := range forms[stem] {
+			if word == "" || formCount > forms[stem][word] || (formCount == forms[stem][word] && form < word) {
+				word = form
+			}
+		}
+		top = append(top, LemmaCount{Lemma: word, Count: count})
+	}
+	slices.SortFunc(top, func(a, b LemmaCount) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Lemma, b.Lemma)
+	})
+	return top[:min(n, len(top))]
+}
+
+// frequencySet возвращает основы слов частотного списка
+func frequencySet(words []string, language string) map[string]bool {
+	if len(words) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[StemLanguage(word, language)] = true
+	}
+	return set
+}
@@ -0,0 +1,76 @@
+package estimator
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeVocabulary(t *testing.T) {
+	text := "The cat saw the other cat. Кот видел кота, а Кот ушёл."
+	lists := map[string][]string{LanguageEnglish: {"the", "saw", "other"}}
+
+	stats := AnalyzeVocabulary(Tokenize(text), lists)
+	if len(stats) != 2 {
+		t.Fatalf("got %d languages, want 2: %+v", len(stats), stats)
+	}
+
+	en := stats[0]
+	if en.Language != LanguageEnglish || en.Tokens != 6 || en.Types != 4 || en.HapaxLegomena != 2 {
+		t.Errorf("en = %+v, want 6 tokens, 4 types, 2 hapax legomena", en)
+	}
+	if want := 4.0 / 6; math.Abs(en.TypeTokenRatio-want) > 1e-9 {
+		t.Errorf("en type/token ratio = %g, want %g", en.TypeTokenRatio, want)
+	}
+	if want := 20.0 / 6; math.Abs(en.AverageWordLength-want) > 1e-9 {
+		t.Errorf("en average word length = %g, want %g", en.AverageWordLength, want)
+	}
+	if top := en.TopLemmas; len(top) != 4 || top[0] != (LemmaCount{"cat", 2}) || top[1] != (LemmaCount{"the", 2}) {
+		t.Errorf("en top lemmas = %v, want cat and the first", top)
+	}
+	if en.FrequencyListSize != 3 || math.Abs(en.OutsideFrequencyList-100.0/3) > 1e-9 {
+		t.Errorf("en outside frequency list = %g%% of %d, want 33.3%% of 3", en.OutsideFrequencyList, en.FrequencyListSize)
+	}
+
+	ru := stats[1]
+	if ru.Language != LanguageRussian || ru.Tokens != 6 || ru.Types != 4 || ru.FrequencyListSize != 0 {
+		t.Errorf("ru = %+v, want 6 tokens, 4 types and no frequency list", ru)
+	}
+	// "кота" — форма слова "кот"
+	if top := ru.TopLemmas[0]; top != (LemmaCount{"кот", 3}) {
+		t.Errorf("ru top lemma = %v, want кот (3)", top)
+	}
+}
+
+func TestMTLD(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  float64
+	}{
+		{"all distinct", strings.Fields("a b c d"), 4},
+		{"repeated pairs", strings.Fields("a a b b c c d d"), 2},
+		// Остаток с долей уникальных 0.8 дает (1 - 0.8) / (1 - 0.72) отрезка
+		{"partial factor", strings.Fields("a b c d a"), 7},
+		{"one word", strings.Fields("a a a a a a"), 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := mtld(test.words); math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("mtld(%v) = %g, want %g", test.words, got, test.want)
+			}
+		})
+	}
+}
+
+func TestReadFrequencyList(t *testing.T) {
+	list := "# rank list\nthe 5000\n\nof 3000\nand 2900\nto 2500\n"
+	words, err := ReadFrequencyList(strings.NewReader(list), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(words, " ") != "the of and" {
+		t.Errorf("ReadFrequencyList = %v, want [the of and]", words)
+	}
+}
@@ -0,0 +1,195 @@
+package estimator
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/rivo/uniseg"
+)
+
+var emojiRegex = regexp.MustCompile(`^(?:[\x{1F1E6}-\x{1F1FF}]{2}|[\x{1F000}-\x{1FAFF}\x{2600}-\x{27BF}\x{2B00}-\x{2BFF}](?:[\x{FE0F}\x{1F3FB}-\x{1F3FF}]|\x{200D}[\x{1F000}-\x{1FAFF}\x{2600}-\x{27BF}])*)$`)
+
+// SegmentWords делит текст на слова по правилам границ слов Unicode (UAX #29) и возвращает
+// слова и эмодзи вместе с их положением в тексте; пробелы и знаки препинания пропускаются.
+//
+// Правила UAX #29 сохраняют апострофы внутри слов ("don't", "O'Brien"), знаки ударения,
+// мягкие переносы и числа с разделителями ("3.14", "1,200"). Поверх них действуют поправки,
+// чтобы подсчет совпадал с текстовыми редакторами:
+//   - слова через дефис, в том числе неразрывный ("кое-как", "non‑breaking"), считаются одним словом;
+//   - диапазон чисел через короткое тире ("1941–1945") и число со знаком процента ("3.5%") — одно слово;
+//   - точка после сокращения с внутренними точками ("т.е.", "e.g.") относится к сокращению.
+//
+// Длинное тире и тире в русском тексте словами не считаются и слова не склеивают.
+// Текст на китайском и японском делится на слова эвристически, без словаря (см. appendCJKWords).
+func SegmentWords(text string) []Span {
+	var segments []Span
+	state := -1
+	for pos, rest := 0, text; len(rest) > 0; {
+		var segment string
+		segment, rest, state = uniseg.FirstWordInString(rest, state)
+		segments = append(segments, Span{Start: pos, End: pos + len(segment), Text: segment})
+		pos += len(segment)
+	}
+
+	var words []Span
+	for i := 0; i < len(segments); i++ {
+		word := segments[i]
+		if isCJKSegment(word.Text) {
+			// Китайский и японский пишутся без пробелов, UAX #29 делит их на отдельные символы
+			j := i + 1
+			for j < len(segments) && isCJKSegment(segments[j].Text) {
+				j++
+			}
+			words = appendCJKWords(words, text, word.Start, segments[j-1].End)
+			i = j - 1
+			continue
+		}
+		if !isWordSegment(word.Text) {
+			if emojiRegex.MatchString(word.Text) {
+				words = append(words, word)
+			}
+			continue
+		}
+
+		// Склеиваем "слово-слово" и "число–число", пока за словом идет соединительный знак и еще одно слово
+		for i+2 < len(segments) && joinsWords(word.Text, segments[i+1].Text, segments[i+2].Text) {
+			word = Span{Start: word.Start, End: segments[i+2].End, Text: text[word.Start:segments[i+2].End]}
+			i += 2
+		}
+		if i+1 < len(segments) && attachesTo(word.Text, segments[i+1].Text) {
+			word = Span{Start: word.Start, End: segments[i+1].End, Text: text[word.Start:segments[i+1].End]}
+			i++
+		}
+		words = append(words, word)
+	}
+	return words
+}
+
+// isWordSegment сообщает, содержит ли сегмент букву или цифру
+func isWordSegment(segment string) bool {
+	return strings.IndexFunc(segment, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
+
+// joinsWords сообщает, соединяет ли знак joiner слова left и right в одно
+func joinsWords(left, joiner, right string) bool {
+	if !isWordSegment(right) {
+		return false
+	}
+	switch joiner {
+	case "-", "‐", "‑": // Дефис, типографский дефис и неразрывный дефис
+		return true
+	case "–":
+		return isNumeric(left) && isNumeric(right)
+	}
+	return false
+}
+
+// attachesTo сообщает, относится ли знак sign, стоящий сразу после слова, к самому слову
+func attachesTo(word, sign string) bool {
+	switch sign {
+	case "%":
+		return isNumeric(word)
+	case ".":
+		return isDottedAbbreviation(word)
+	}
+	return false
+}
+
+// isNumeric сообщает, состоит ли слово из цифр и разделителей разрядов
+func isNumeric(word string) bool {
+	hasDigit := false
+	for _, r := range word {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case r != '.' && r != ',' && r != '–':
+			return false
+		}
+	}
+	return hasDigit
+}
+
+// isDottedAbbreviation сообщает, является ли слово сокращением из частей по одной-две буквы через точку: "т.е", "e.g", "J.R.R"
+func isDottedAbbreviation(word string) bool {
+	parts := strings.Split(word, ".")
+	if len(parts) < 2 {
+		return false
+	}
+	for _, part := range parts {
+		n := 0
+		for _, r := range part {
+			if !unicode.IsLetter(r) {
+				return false
+			}
+			n++
+		}
+		if n == 0 || n > 2 {
+			return false
+		}
+	}
+	return true
+}
+
+// cjkScript — письменность символа китайского или японского текста
+type cjkScript int
+
+const (
+	scriptOther cjkScript = iota
+	scriptHan
+	scriptHiragana
+	scriptKatakana
+)
+
+func scriptOf(r rune) cjkScript {
+	switch {
+	case unicode.Is(unicode.Han, r):
+		return scriptHan
+	case unicode.Is(unicode.Hiragana, r):
+		return scriptHiragana
+	case unicode.Is(unicode.Katakana, r), r == 'ー':
+		return scriptKatakana
+	}
+	return scriptOther
+}
+
+// isCJKSegment сообщает, начинается ли сегмент с иероглифа или знака каны
+func isCJKSegment(segment string) bool {
+	for _, r := range segment {
+		return scriptOf(r) != scriptOther
+	}
+	return false
+}
+
+// appendCJKWords делит фрагмент text[start:end] из иероглифов и каны на приблизительные слова.
+// В японском тексте слово начинается на границе письменностей: катакана идет отдельным словом,
+// а хирагана после иероглифов считается окончанием ("読みます"). Китайский текст без каны делится
+// на пары иероглифов — средняя длина китайского слова около двух символов.
+func appendCJKWords(words []Span, text string, start, end int) []Span {
+	run := text[start:end]
+	japanese := strings.IndexFunc(run, func(r rune) bool {
+		script := scriptOf(r)
+		return script == scriptHiragana || script == scriptKatakana
+	}) >= 0
+
+	wordStart, length := 0, 0
+	prev := scriptOther
+	for i, r := range run {
+		script := scriptOf(r)
+		var boundary bool
+		if japanese {
+			boundary = script != prev && !(prev == scriptHan && script == scriptHiragana)
+		} else {
+			boundary = length == 2
+		}
+		if boundary && i > wordStart {
+			words = append(words, Span{Start: start + wordStart, End: start + i, Text: run[wordStart:i]})
+			wordStart, length = i, 0
+		}
+		prev = script
+		length++
+	}
+	return append(words, Span{Start: start + wordStart, End: end, Text: run[wordStart:]})
+}
@@ -0,0 +1,75 @@
+package estimator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSegmentWords(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		{"I don't know O'Brien's cat", []string{"I", "don't", "know", "O'Brien's", "cat"}},
+		{"We don’t rock’n’roll", []string{"We", "don’t", "rock’n’roll"}},
+		{"Кое-как из‑за non‑breaking", []string{"Кое-как", "из‑за", "non‑breaking"}},
+		{"Война — это 1941–1945 годы", []string{"Война", "это", "1941–1945", "годы"}},
+		{"Москва—Петербург", []string{"Москва", "Петербург"}},
+		{"Рост 3.5% и 1,200 шт.", []string{"Рост", "3.5%", "и", "1,200", "шт"}},
+		{"т.е. e.g. J.R.R. Tolkien", []string{"т.е.", "e.g.", "J.R.R.", "Tolkien"}},
+		{"замо́к и пере­нос", []string{"замо́к", "и", "пере­нос"}},
+		{"«Привет», — сказал он… (тихо)", []string{"Привет", "сказал", "он", "тихо"}},
+		{"Ура 🎉🇷🇺", []string{"Ура", "🎉", "🇷🇺"}},
+		{"我们学习中文。", []string{"我们", "学习", "中文"}},
+		{"私はコーヒーを飲みます", []string{"私は", "コーヒー", "を", "飲みます"}},
+		{"Go语言很好", []string{"Go", "语言", "很好"}},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("text=%q", test.text), func(t *testing.T) {
+			spans := SegmentWords(test.text)
+			if got := spanTexts(spans); strings.Join(got, "|") != strings.Join(test.expected, "|") {
+				t.Fatalf("SegmentWords(%q) = %q; want %q", test.text, got, test.expected)
+			}
+			for i, span := range spans {
+				if test.text[span.Start:span.End] != span.Text {
+					t.Errorf("Word %d offsets [%d:%d] do not match its text", i, span.Start, span.End)
+				}
+			}
+		})
+	}
+}
+
+func TestEstimateCJK(t *testing.T) {
+	// 255 иероглифов читаются за минуту независимо от скорости чтения в словах
+	text := strings.Repeat("我们今天学习中文。", 255/8) + "好好好好好好好。"
+	result, err := Estimate(text, Options{ReadingSpeed: 100, Workers: 2})
+	if err != nil {
+		t.Fatalf("Estimate() returned error: %v", err)
+	}
+	if result.ReadingTime != 1 {
+		t.Errorf("ReadingTime = %.2f; want 1.00", result.ReadingTime)
+	}
+	if result.SentenceCount != 255/8+1 {
+		t.Errorf("SentenceCount = %d; want %d", result.SentenceCount, 255/8+1)
+	}
+	if result.TokenCounts[TokenCJK] != result.WordCount {
+		t.Errorf("TokenCounts[cjk] = %d; want all %d words", result.TokenCounts[TokenCJK], result.WordCount)
+	}
+	if !result.FleschKincaidNotApplicable {
+		t.Errorf("FleschKincaidNotApplicable = false for CJK text, index %.2f", result.FleschKincaidIndex)
+	}
+
+	// В смешанном тексте время складывается из времени на слова и на иероглифы
+	mixed, err := Estimate("Hello world. "+text, Options{ReadingSpeed: 100, Workers: 2, CharactersPerMinute: 510})
+	if err != nil {
+		t.Fatalf("Estimate() returned error: %v", err)
+	}
+	if mixed.ReadingTime != 0.52 {
+		t.Errorf("Mixed ReadingTime = %.2f; want 0.52", mixed.ReadingTime)
+	}
+	if mixed.FleschKincaidNotApplicable {
+		t.Error("FleschKincaidNotApplicable = true for text with English words")
+	}
+}
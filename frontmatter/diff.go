@@ -0,0 +1,172 @@
+package frontmatter
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Строки контекста вокруг изменений, как у diff -u
+const diffContext = 3
+
+// Операция построчного сравнения
+type diffOp struct {
+	kind byte // ' ', '-' или '+'
+	line string
+}
+
+// UnifiedDiff возвращает изменения между двумя версиями файла в формате diff -u;
+// пустая строка означает, что версии совпадают
+func UnifiedDiff(name, old, new string) string {
+	if old == new {
+		return ""
+	}
+	ops := diffLines(splitLines(old), splitLines(new))
+
+	// Относительные пути помечаются префиксами a/ и b/, как в git diff
+	oldName, newName := name, name
+	if !filepath.IsAbs(name) {
+		oldName, newName = "a/"+name, "b/"+name
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(ops); {
+		// Ищем следующее изменение и захватываем контекст вокруг него
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		from := max(first-diffContext, start)
+		to := first
+		for unchanged := 0; to < len(ops) && unchanged <= 2*diffContext; to++ {
+			if ops[to].kind == ' ' {
+				unchanged++
+			} else {
+				unchanged = 0
+			}
+		}
+		// Хвост из неизмененных строк укорачиваем до контекста
+		for to > first && ops[to-1].kind == ' ' && countTrailing(ops[first:to]) > diffContext {
+			to--
+		}
+
+		oldStart, newStart := lineNumbers(ops[:from])
+		oldCount, newCount := 0, 0
+		for _, op := range ops[from:to] {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+		for _, op := range ops[from:to] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			b.WriteByte('\n')
+		}
+		start = to
+	}
+	return b.String()
+}
+
+func splitLines(text string) []string {
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, "\r\n")
+	}
+	return lines
+}
+
+// diffLines сравнивает строки по наибольшей общей подпоследовательности.
+// Общие начало и конец отбрасываются заранее: обычно меняется только front matter.
+func diffLines(old, new []string) []diffOp {
+	prefix := 0
+	for prefix < len(old) && prefix < len(new) && old[prefix] == new[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(old)-prefix && suffix < len(new)-prefix && old[len(old)-1-suffix] == new[len(new)-1-suffix] {
+		suffix++
+	}
+	a, b := old[prefix:len(old)-suffix], new[prefix:len(new)-suffix]
+
+	// lcs[i][j] — длина общей подпоследовательности a[i:] и b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffOp, 0, len(old)+len(new))
+	for _, line := range old[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i]})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j]})
+			j++
+		}
+	}
+	for _, line := range old[len(old)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+func countTrailing(ops []diffOp) int {
+	n := 0
+	for i := len(ops) - 1; i >= 0 && ops[i].kind == ' '; i-- {
+		n++
+	}
+	return n
+}
+
+// lineNumbers возвращает номера строк старой и новой версии, следующих за ops
+func lineNumbers(ops []diffOp) (int, int) {
+	oldLine, newLine := 1, 1
+	for _, op := range ops {
+		if op.kind != '+' {
+			oldLine++
+		}
+		if op.kind != '-' {
+			newLine++
+		}
+	}
+	return oldLine, newLine
+}
+
+func hunkRange(start, count int) string {
+	if count == 0 {
+		// Пустой диапазон указывает на строку перед ним
+		return fmt.Sprintf("%d,0", start-1)
+	}
+	if count == 1 {
+		return fmt.Sprint(start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
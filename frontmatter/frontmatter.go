@@ -0,0 +1,209 @@
+package frontmatter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Format — формат блока front matter
+type Format string
+
+const (
+	FormatYAML Format = "yaml" // Между строками "---", как у Jekyll и Hugo
+	FormatTOML Format = "toml" // Между строками "+++", как у Hugo
+)
+
+// ParseFormat разбирает название формата front matter
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(strings.TrimSpace(name))); format {
+	case FormatYAML, FormatTOML:
+		return format, nil
+	case "yml":
+		return FormatYAML, nil
+	default:
+		return "", fmt.Errorf("unknown front matter format %q (supported: %s, %s)", name, FormatYAML, FormatTOML)
+	}
+}
+
+func (f Format) delimiter() string {
+	if f == FormatTOML {
+		return "+++"
+	}
+	return "---"
+}
+
+// Field — поле front matter с числовым или строковым значением
+type Field struct {
+	Key   string
+	Value any
+}
+
+// Document — текст с разобранным блоком front matter. Строки блока хранятся как есть,
+// поэтому при изменении полей остальные ключи, комментарии и оформление сохраняются.
+type Document struct {
+	Format  Format   // Пустой, если блока нет
+	Lines   []string // Строки блока без разделителей
+	Body    string   // Текст после блока
+	newline string
+	closing string // Закрывающий разделитель: в YAML это может быть "..."
+	bom     bool
+}
+
+// Parse выделяет front matter в начале текста. Текст без блока целиком считается телом.
+func Parse(content string) (*Document, error) {
+	doc := &Document{Body: content, newline: "\n"}
+	if strings.Contains(content, "\r\n") {
+		doc.newline = "\r\n"
+	}
+
+	// Знак порядка байтов перед разделителем не мешает генераторам сайтов
+	text, bom := strings.CutPrefix(content, "\uFEFF")
+	first, rest, ok := strings.Cut(text, "\n")
+	first = strings.TrimRight(first, "\r")
+	var format Format
+	switch first {
+	case FormatYAML.delimiter():
+		format = FormatYAML
+	case FormatTOML.delimiter():
+		format = FormatTOML
+	default:
+		return doc, nil
+	}
+	if !ok {
+		return nil, fmt.Errorf("front matter is not closed with %q", first)
+	}
+
+	var lines []string
+	for {
+		line, next, more := strings.Cut(rest, "\n")
+		trimmed := strings.TrimRight(line, "\r")
+		if trimmed == first || (format == FormatYAML && trimmed == "...") {
+			doc.Format, doc.Lines, doc.Body, doc.closing, doc.bom = format, lines, next, trimmed, bom
+			return doc, nil
+		}
+		if !more {
+			return nil, fmt.Errorf("front matter is not closed with %q", first)
+		}
+		lines = append(lines, trimmed)
+		rest = next
+	}
+}
+
+// Set задает значения полей верхнего уровня: существующие строки заменяются на месте
+// с сохранением комментариев, новые поля добавляются в конец блока.
+// Если блока нет, он создается в формате format.
+func (d *Document) Set(fields []Field, format Format) {
+	if d.Format == "" {
+		d.Format, d.closing = format, format.delimiter()
+	}
+	for _, field := range fields {
+		value := formatValue(field.Value)
+		if i, ok := d.find(field.Key); ok {
+			d.Lines[i] = d.line(field.Key, value) + comment(d.Lines[i])
+			// Многострочное значение YAML заменяется одной строкой
+			for d.Format == FormatYAML && i+1 < len(d.Lines) && isContinuation(d.Lines[i+1]) {
+				d.Lines = append(d.Lines[:i+1], d.Lines[i+2:]...)
+			}
+			continue
+		}
+		d.Lines = d.insert(d.line(field.Key, value))
+	}
+}
+
+// String собирает текст из блока front matter и тела
+func (d *Document) String() string {
+	if d.Format == "" {
+		return d.Body
+	}
+	var b strings.Builder
+	if d.bom {
+		b.WriteString("\uFEFF")
+	}
+	b.WriteString(d.Format.delimiter() + d.newline)
+	for _, line := range d.Lines {
+		b.WriteString(line + d.newline)
+	}
+	b.WriteString(d.closing + d.newline)
+	b.WriteString(d.Body)
+	return b.String()
+}
+
+func (d *Document) line(key, value string) string {
+	if d.Format == FormatTOML {
+		return key + " = " + value
+	}
+	return key + ": " + value
+}
+
+// find ищет строку поля верхнего уровня; в TOML — до первой таблицы
+func (d *Document) find(key string) (int, bool) {
+	separator := ":"
+	if d.Format == FormatTOML {
+		separator = "="
+	}
+	keyRegex := regexp.MustCompile(`^` + regexp.QuoteMeta(key) + `\s*` + separator)
+	for i, line := range d.Lines {
+		if d.Format == FormatTOML && isTable(line) {
+			break
+		}
+		if keyRegex.MatchString(line) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// insert добавляет строку в конец полей верхнего уровня. В TOML поля после заголовка
+// таблицы относятся к ней, поэтому строка вставляется перед первой таблицей.
+func (d *Document) insert(line string) []string {
+	at := len(d.Lines)
+	if d.Format == FormatTOML {
+		for i, l := range d.Lines {
+			if isTable(l) {
+				at = i
+				// Пустые строки перед таблицей остаются перед ней
+				for at > 0 && strings.TrimSpace(d.Lines[at-1]) == "" {
+					at--
+				}
+				break
+			}
+		}
+	}
+	lines := make([]string, 0, len(d.Lines)+1)
+	lines = append(lines, d.Lines[:at]...)
+	lines = append(lines, line)
+	return append(lines, d.Lines[at:]...)
+}
+
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return strconv.Quote(v)
+	default:
+		return strconv.Quote(fmt.Sprint(v))
+	}
+}
+
+var commentRegex = regexp.MustCompile(`\s+#.*$`)
+
+// comment возвращает комментарий в конце строки, если значение не строка в кавычках
+func comment(line string) string {
+	if strings.ContainsAny(line, `"'`) {
+		return ""
+	}
+	return commentRegex.FindString(line)
+}
+
+func isContinuation(line string) bool {
+	return line != "" && (line[0] == ' ' || line[0] == '\t')
+}
+
+func isTable(line string) bool {
+	return strings.HasPrefix(strings.TrimSpace(line), "[")
+}
@@ -0,0 +1,88 @@
+package frontmatter
+
+import (
+	"strings"
+	"testing"
+)
+
+var fields = []Field{
+	{Key: "reading_time", Value: 2.5},
+	{Key: "word_count", Value: 480},
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "yaml keeps keys and comments",
+			content: "---\ntitle: \"Hello\"  # post title\nreading_time: 1 # minutes\ntags:\n  - go\n---\nBody text\n",
+			want:    "---\ntitle: \"Hello\"  # post title\nreading_time: 2.5 # minutes\ntags:\n  - go\nword_count: 480\n---\nBody text\n",
+		},
+		{
+			name:    "yaml list value becomes a scalar",
+			content: "---\nword_count:\n  - 1\ntitle: x\n---\n",
+			want:    "---\nword_count: 480\ntitle: x\nreading_time: 2.5\n---\n",
+		},
+		{
+			name:    "toml inserts before tables",
+			content: "+++\ntitle = 'Hello'\n\n[params]\nauthor = 'me'\n+++\nBody\n",
+			want:    "+++\ntitle = 'Hello'\nreading_time = 2.5\nword_count = 480\n\n[params]\nauthor = 'me'\n+++\nBody\n",
+		},
+		{
+			name:    "new block",
+			content: "Just text\n",
+			want:    "---\nreading_time: 2.5\nword_count: 480\n---\nJust text\n",
+		},
+		{
+			name:    "crlf line endings",
+			content: "---\r\ntitle: x\r\n---\r\nBody\r\n",
+			want:    "---\r\ntitle: x\r\nreading_time: 2.5\r\nword_count: 480\r\n---\r\nBody\r\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			doc, err := Parse(test.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			doc.Set(fields, FormatYAML)
+			if got := doc.String(); got != test.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseUnclosed(t *testing.T) {
+	if _, err := Parse("---\ntitle: x\nBody\n"); err == nil {
+		t.Error("expected an error for front matter without the closing delimiter")
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	old := "---\ntitle: x\nreading_time: 1\n---\n" + strings.Repeat("body\n", 10)
+	new := "---\ntitle: x\nreading_time: 2\nword_count: 5\n---\n" + strings.Repeat("body\n", 10)
+
+	want := `--- a/post.md
++++ b/post.md
+@@ -1,6 +1,7 @@
+ ---
+ title: x
+-reading_time: 1
++reading_time: 2
++word_count: 5
+ ---
+ body
+ body
+`
+	if got := UnifiedDiff("post.md", old, new); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := UnifiedDiff("post.md", old, old); got != "" {
+		t.Errorf("diff of equal texts = %q, want empty", got)
+	}
+}
@@ -0,0 +1,335 @@
+package gitdelta
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"LitTime/estimator"
+)
+
+// DefaultPatterns — шаблоны имен файлов документации, которые оцениваются по умолчанию
+var DefaultPatterns = []string{"*.md", "*.markdown", "*.txt", "*.rst", "*.adoc"}
+
+// ErrInvalidRange возвращается для диапазона, который не удалось разобрать или найти в репозитории
+var ErrInvalidRange = errors.New("invalid revision range")
+
+// Status описывает, что произошло с файлом в коммите
+type Status string
+
+const (
+	StatusAdded    Status = "added"
+	StatusModified Status = "modified"
+	StatusDeleted  Status = "deleted"
+	StatusRenamed  Status = "renamed"
+)
+
+// FileDelta — изменение времени чтения одного файла
+type FileDelta struct {
+	Path     string  `json:"path" yaml:"path"`
+	OldPath  string  `json:"old_path,omitempty" yaml:"old_path,omitempty"` // Прежний путь переименованного файла
+	Status   Status  `json:"status" yaml:"status"`
+	OldTime  float64 `json:"old_time" yaml:"old_time"` // Время чтения до изменения в минутах
+	NewTime  float64 `json:"new_time" yaml:"new_time"`
+	Delta    float64 `json:"delta" yaml:"delta"`
+	OldWords int     `json:"old_words" yaml:"old_words"`
+	NewWords int     `json:"new_words" yaml:"new_words"`
+}
+
+// CommitDelta — изменение времени чтения документации в коммите относительно первого родителя
+type CommitDelta struct {
+	Hash    string      `json:"hash" yaml:"hash"`
+	Subject string      `json:"subject" yaml:"subject"`
+	Author  string      `json:"author" yaml:"author"`
+	Time    time.Time   `json:"time" yaml:"time"`
+	Delta   float64     `json:"delta" yaml:"delta"`
+	Files   []FileDelta `json:"files" yaml:"files"`
+}
+
+// Report — изменение времени чтения по коммитам диапазона и в целом
+type Report struct {
+	Range   string        `json:"range" yaml:"range"`
+	Base    string        `json:"base,omitempty" yaml:"base,omitempty"` // Общий предок, относительно которого считается итог
+	Head    string        `json:"head" yaml:"head"`
+	Commits []CommitDelta `json:"commits" yaml:"commits"`
+	Files   []FileDelta   `json:"files" yaml:"files"` // Итоговое изменение файлов от Base до Head
+	Total   float64       `json:"total" yaml:"total"`
+}
+
+// Estimate оценивает текст одной версии файла
+type Estimate func(text string) (*estimator.Result, error)
+
+// Analyzer оценивает документацию в коммитах локального репозитория
+type Analyzer struct {
+	repo     *git.Repository
+	patterns []string
+	estimate Estimate
+	// Результаты по хэшу содержимого: одна и та же версия файла встречается во многих коммитах
+	results map[plumbing.Hash]*estimator.Result
+}
+
+// Open открывает репозиторий, в который входит каталог dir. Сеть не используется.
+func Open(dir string, patterns []string, estimate Estimate) (*Analyzer, error) {
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+		}
+	}
+	return &Analyzer{repo: repo, patterns: patterns, estimate: estimate, results: make(map[plumbing.Hash]*estimator.Result)}, nil
+}
+
+// Analyze оценивает изменения документации в диапазоне ревизий.
+// Диапазон "A..B" включает коммиты, достижимые из B и недостижимые из A, как в git log;
+// пропущенная ревизия означает HEAD. Одна ревизия означает один коммит.
+// Коммиты слияния не выводятся: их изменения уже учтены в сливаемых коммитах.
+// Итог считается от общего предка A и B до B, как изменения в запросе на слияние.
+func (a *Analyzer) Analyze(revRange string) (Report, error) {
+	from, to, isRange := strings.Cut(revRange, "..")
+	if strings.HasPrefix(to, ".") {
+		return Report{}, fmt.Errorf("%w %q: symmetric difference is not supported", ErrInvalidRange, revRange)
+	}
+	head, err := a.commit(to)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Range: revRange, Head: head.Hash.String()}
+	var commits []*object.Commit
+	var base *object.Commit
+	if isRange {
+		if base, err = a.commit(from); err != nil {
+			return Report{}, err
+		}
+		if commits, err = a.between(base, head); err != nil {
+			return Report{}, err
+		}
+		// У несвязанных историй общего предка нет, итог тогда считается от пустого дерева
+		bases, err := head.MergeBase(base)
+		if err != nil {
+			return Report{}, err
+		}
+		base = nil
+		if len(bases) > 0 {
+			base = bases[0]
+		}
+	} else {
+		commits = []*object.Commit{head}
+		if base, err = firstParent(head); err != nil {
+			return Report{}, err
+		}
+	}
+	if base != nil {
+		report.Base = base.Hash.String()
+	}
+
+	for _, c := range commits {
+		if c.NumParents() > 1 {
+			continue
+		}
+		parent, err := firstParent(c)
+		if err != nil {
+			return Report{}, err
+		}
+		files, err := a.compare(parent, c)
+		if err != nil {
+			return Report{}, fmt.Errorf("commit %s: %w", c.Hash.String()[:7], err)
+		}
+		subject, _, _ := strings.Cut(strings.TrimSpace(c.Message), "\n")
+		report.Commits = append(report.Commits, CommitDelta{
+			Hash:    c.Hash.String(),
+			Subject: subject,
+			Author:  c.Author.Name,
+			Time:    c.Author.When,
+			Delta:   total(files),
+			Files:   files,
+		})
+	}
+
+	if report.Files, err = a.compare(base, head); err != nil {
+		return Report{}, err
+	}
+	report.Total = total(report.Files)
+	return report, nil
+}
+
+// commit находит коммит по ревизии; пустая ревизия означает HEAD
+func (a *Analyzer) commit(rev string) (*object.Commit, error) {
+	if rev == "" {
+		rev = "HEAD"
+	}
+	hash, err := a.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRange, rev, err)
+	}
+	commit, err := a.repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidRange, rev, err)
+	}
+	return commit, nil
+}
+
+// between возвращает коммиты, достижимые из head и недостижимые из base, от старых к новым
+func (a *Analyzer) between(base, head *object.Commit) ([]*object.Commit, error) {
+	excluded := make(map[plumbing.Hash]bool)
+	err := object.NewCommitPreorderIter(base, nil, nil).ForEach(func(c *object.Commit) error {
+		excluded[c.Hash] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []*object.Commit
+	err = object.NewCommitPreorderIter(head, excluded, nil).ForEach(func(c *object.Commit) error {
+		commits = append(commits, c)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(commits)
+	slices.SortStableFunc(commits, func(x, y *object.Commit) int {
+		return x.Committer.When.Compare(y.Committer.When)
+	})
+	return commits, nil
+}
+
+func firstParent(c *object.Commit) (*object.Commit, error) {
+	if c.NumParents() == 0 {
+		return nil, nil
+	}
+	return c.Parent(0)
+}
+
+// compare оценивает документацию, изменившуюся между двумя коммитами; nil — пустое дерево
+func (a *Analyzer) compare(old, new *object.Commit) ([]FileDelta, error) {
+	var oldTree, newTree *object.Tree
+	var err error
+	if old != nil {
+		if oldTree, err = old.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	if new != nil {
+		if newTree, err = new.Tree(); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := object.DiffTreeWithOptions(context.Background(), oldTree, newTree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []FileDelta
+	for _, change := range changes {
+		if !a.matches(change.From.Name) && !a.matches(change.To.Name) {
+			continue
+		}
+		from, to, err := change.Files()
+		if err != nil {
+			return nil, err
+		}
+
+		delta := FileDelta{Path: change.To.Name, Status: StatusModified}
+		switch {
+		case from == nil:
+			delta.Status = StatusAdded
+		case to == nil:
+			delta.Path, delta.Status = change.From.Name, StatusDeleted
+		case change.From.Name != change.To.Name:
+			delta.OldPath, delta.Status = change.From.Name, StatusRenamed
+		}
+
+		// Переименование в файл с другим расширением оцениваем только с той стороны, что подходит под шаблоны
+		if from != nil && a.matches(change.From.Name) {
+			result, err := a.result(from)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", change.From.Name, err)
+			}
+			delta.OldTime, delta.OldWords = result.ReadingTime, result.WordCount
+		}
+		if to != nil && a.matches(change.To.Name) {
+			result, err := a.result(to)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", change.To.Name, err)
+			}
+			delta.NewTime, delta.NewWords = result.ReadingTime, result.WordCount
+		}
+		delta.Delta = round(delta.NewTime - delta.OldTime)
+		files = append(files, delta)
+	}
+	return files, nil
+}
+
+// matches проверяет путь по шаблонам: шаблон без "/" сравнивается с именем файла, иначе — с путем целиком
+func (a *Analyzer) matches(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, pattern := range a.patterns {
+		target := path.Base(name)
+		if strings.Contains(pattern, "/") {
+			target = name
+		}
+		if ok, _ := path.Match(pattern, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// result оценивает версию файла. Пустой и двоичный файл читать не нужно.
+func (a *Analyzer) result(file *object.File) (*estimator.Result, error) {
+	if result, ok := a.results[file.Hash]; ok {
+		return result, nil
+	}
+
+	result := &estimator.Result{}
+	binary, err := file.IsBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !binary {
+		reader, err := file.Reader()
+		if err != nil {
+			return nil, err
+		}
+		text, err := estimator.ReadText(reader)
+		reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		if result, err = a.estimate(text); errors.Is(err, estimator.ErrEmptyText) {
+			result = &estimator.Result{}
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	a.results[file.Hash] = result
+	return result, nil
+}
+
+func total(files []FileDelta) float64 {
+	var sum float64
+	for _, f := range files {
+		sum += f.Delta
+	}
+	return round(sum)
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,118 @@
+package gitdelta
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+
+	"LitTime/estimator"
+)
+
+// wordEstimate считает минуту на каждые десять слов, чтобы ожидания не зависели от модели чтения
+func wordEstimate(text string) (*estimator.Result, error) {
+	words, _ := estimator.CountWords(text)
+	if words == 0 {
+		return nil, estimator.ErrEmptyText
+	}
+	return &estimator.Result{ReadingTime: float64(words) / 10, WordCount: words}, nil
+}
+
+var commitTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func commitFiles(t *testing.T, repo *git.Repository, dir, message string, files map[string]string) {
+	t.Helper()
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Коммиты расходятся во времени, чтобы их порядок был однозначным
+	commitTime = commitTime.Add(time.Minute)
+	signature := &object.Signature{Name: "Test", Email: "test@example.com", When: commitTime}
+	if _, err := worktree.Commit(message, &git.CommitOptions{Author: signature, Committer: signature}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tenWords := strings.Repeat("word ", 10)
+	commitFiles(t, repo, dir, "Initial", map[string]string{"README.md": tenWords, "main.go": "package main"})
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference("refs/heads/base", head.Hash())); err != nil {
+		t.Fatal(err)
+	}
+	commitFiles(t, repo, dir, "Add guide\n\nLong description", map[string]string{"docs/guide.md": tenWords + tenWords})
+	commitFiles(t, repo, dir, "Change code", map[string]string{"main.go": "package main\n\nfunc main() {}"})
+	commitFiles(t, repo, dir, "Shorten readme", map[string]string{"README.md": "word word word word word"})
+
+	analyzer, err := Open(filepath.Join(dir, "docs"), DefaultPatterns, wordEstimate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	report, err := analyzer.Analyze("base..")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Commits) != 3 {
+		t.Fatalf("got %d commits, want 3", len(report.Commits))
+	}
+	wantDeltas := []float64{2, 0, -0.5}
+	for i, c := range report.Commits {
+		if c.Delta != wantDeltas[i] {
+			t.Errorf("commit %d (%s): delta = %v, want %v", i, c.Subject, c.Delta, wantDeltas[i])
+		}
+	}
+	if report.Commits[0].Subject != "Add guide" {
+		t.Errorf("subject = %q, want the first line of the message", report.Commits[0].Subject)
+	}
+	if len(report.Commits[1].Files) != 0 {
+		t.Errorf("code change reported as documentation: %+v", report.Commits[1].Files)
+	}
+	if added := report.Commits[0].Files[0]; added.Status != StatusAdded || added.Path != "docs/guide.md" || added.NewWords != 20 {
+		t.Errorf("added file = %+v", added)
+	}
+	if report.Total != 1.5 || len(report.Files) != 2 {
+		t.Errorf("total = %v in %d files, want 1.5 in 2", report.Total, len(report.Files))
+	}
+
+	// Одна ревизия — один коммит относительно родителя
+	single, err := analyzer.Analyze("HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(single.Commits) != 1 || single.Total != -0.5 {
+		t.Errorf("single commit: %d commits, total %v", len(single.Commits), single.Total)
+	}
+
+	if _, err := analyzer.Analyze("missing..HEAD"); !errors.Is(err, ErrInvalidRange) {
+		t.Errorf("unknown revision: err = %v, want ErrInvalidRange", err)
+	}
+}
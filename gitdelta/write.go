@@ -0,0 +1,127 @@
+package gitdelta
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	"LitTime/output"
+)
+
+// Formats возвращает форматы, в которых можно вывести отчет
+func Formats() []output.Format {
+	return []output.Format{output.FormatText, output.FormatMarkdown, output.FormatJSON, output.FormatYAML}
+}
+
+// Write записывает отчет в w в одном из форматов Formats
+func Write(w io.Writer, r Report, format output.Format) error {
+	switch format {
+	case output.FormatText:
+		return writeText(w, r)
+	case output.FormatMarkdown:
+		return writeMarkdown(w, r)
+	case output.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	case output.FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(r); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("report cannot be written as %s", format)
+	}
+}
+
+// name возвращает путь файла, для переименованного — вместе с прежним
+func (f FileDelta) name() string {
+	if f.OldPath != "" {
+		return f.OldPath + " → " + f.Path
+	}
+	return f.Path
+}
+
+func short(hash string) string {
+	return hash[:min(len(hash), 7)]
+}
+
+func minutes(value float64) string {
+	return fmt.Sprintf("%+.2f min", value)
+}
+
+func files(n int) string {
+	if n == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", n)
+}
+
+func writeText(w io.Writer, r Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r.Commits {
+		if len(c.Files) == 0 {
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", short(c.Hash), minutes(c.Delta), c.Subject)
+		for _, f := range c.Files {
+			fmt.Fprintf(tw, "\t%s\t  %s (%s)\n", minutes(f.Delta), f.name(), f.Status)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	base := "root"
+	if r.Base != "" {
+		base = short(r.Base)
+	}
+	changed := 0
+	for _, c := range r.Commits {
+		if len(c.Files) > 0 {
+			changed++
+		}
+	}
+	if changed == 0 {
+		fmt.Fprintln(w, "No documentation changes")
+	} else {
+		fmt.Fprintln(w)
+	}
+	_, err := fmt.Fprintf(w, "Total: %s in %s (%s..%s, %d of %d commits change documentation)\n",
+		minutes(r.Total), files(len(r.Files)), base, short(r.Head), changed, len(r.Commits))
+	return err
+}
+
+func writeMarkdown(w io.Writer, r Report) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "**Reading time change:** %s in %s\n", minutes(r.Total), files(len(r.Files)))
+	if len(r.Files) > 0 {
+		b.WriteString("\n| File | Change | Before | After |\n")
+		b.WriteString("| --- | ---: | ---: | ---: |\n")
+		for _, f := range r.Files {
+			fmt.Fprintf(&b, "| %s | %s | %.2f | %.2f |\n", strings.ReplaceAll(f.name(), "|", `\|`), minutes(f.Delta), f.OldTime, f.NewTime)
+		}
+	}
+
+	header := false
+	for _, c := range r.Commits {
+		if len(c.Files) == 0 {
+			continue
+		}
+		if !header {
+			b.WriteString("\n| Commit | Change | Subject |\n")
+			b.WriteString("| --- | ---: | --- |\n")
+			header = true
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", short(c.Hash), minutes(c.Delta), strings.ReplaceAll(c.Subject, "|", `\|`))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,362 @@
+package history
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"gopkg.in/yaml.v3"
+
+	"LitTime/estimator"
+	"LitTime/output"
+)
+
+// Корневой бакет; внутри него у каждого файла свой бакет с записями, упорядоченными по времени
+var runsBucket = []byte("runs")
+
+// ErrNoHistory возвращается, если для файла нет сохраненных оценок
+var ErrNoHistory = errors.New("no history")
+
+// Entry — одна сохраненная оценка документа
+type Entry struct {
+	Time    time.Time `json:"time" yaml:"time"`
+	Path    string    `json:"path" yaml:"path"` // Абсолютный путь к файлу
+	Hash    string    `json:"hash" yaml:"hash"` // SHA-256 текста в шестнадцатеричном виде
+	Profile string    `json:"profile,omitempty" yaml:"profile,omitempty"`
+
+	Mode               estimator.ReadingMode `json:"mode" yaml:"mode"`
+	ReadingSpeed       float64               `json:"reading_speed" yaml:"reading_speed"`
+	ReadingTime        float64               `json:"reading_time" yaml:"reading_time"`
+	WordCount          int                   `json:"word_count" yaml:"word_count"`
+	SentenceCount      int                   `json:"sentence_count" yaml:"sentence_count"`
+	SyllableCount      int                   `json:"syllable_count" yaml:"syllable_count"`
+	FleschKincaidIndex float64               `json:"flesch_kincaid_index" yaml:"flesch_kincaid_index"`
+
+	// Индекс читаемости неприменим к тексту (см. estimator.Result.FleschKincaidNotApplicable)
+	FleschKincaidNotApplicable bool `json:"flesch_kincaid_not_applicable,omitempty" yaml:"flesch_kincaid_not_applicable,omitempty"`
+}
+
+// legacyEntry — запись в том виде, в котором ее сохраняли до появления json-тегов: с именами полей Go
+type legacyEntry struct {
+	Time    time.Time
+	Path    string
+	Hash    string
+	Profile string
+
+	Mode               estimator.ReadingMode
+	ReadingSpeed       float64
+	ReadingTime        float64
+	WordCount          int
+	SentenceCount      int
+	SyllableCount      int
+	FleschKincaidIndex float64
+
+	FleschKincaidNotApplicable bool
+}
+
+// UnmarshalJSON читает запись и в текущем виде, и в виде legacyEntry, чтобы старая история не терялась
+func (e *Entry) UnmarshalJSON(data []byte) error {
+	// Ключи без тегов сравниваются без учета регистра, поэтому старую запись выдает только ключ из нескольких слов
+	var probe struct{ ReadingTime *float64 }
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	if probe.ReadingTime != nil {
+		var legacy legacyEntry
+		if err := json.Unmarshal(data, &legacy); err != nil {
+			return err
+		}
+		*e = Entry(legacy)
+		return nil
+	}
+	type entry Entry
+	return json.Unmarshal(data, (*entry)(e))
+}
+
+// Document — сводка по одному файлу в истории
+type Document struct {
+	Path string
+	Runs int
+	Last Entry
+}
+
+// Hash возвращает SHA-256 текста в шестнадцатеричном виде
+func Hash(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+// NewEntry создает запись об оценке текста из файла path
+func NewEntry(path, text string, result *estimator.Result, opts estimator.Options) (Entry, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Entry{}, err
+	}
+	return Entry{
+		Time:               time.Now(),
+		Path:               abs,
+		Hash:               Hash(text),
+		Mode:               result.Mode,
+		ReadingSpeed:       opts.ReadingSpeed,
+		ReadingTime:        result.ReadingTime,
+		WordCount:          result.WordCount,
+		SentenceCount:      result.SentenceCount,
+		SyllableCount:      result.SyllableCount,
+		FleschKincaidIndex: result.FleschKincaidIndex,
+
+		FleschKincaidNotApplicable: result.FleschKincaidNotApplicable,
+	}, nil
+}
+
+// DefaultPath возвращает путь к истории по спецификации XDG: $XDG_DATA_HOME/littime/history.db
+// или ~/.local/share/littime/history.db
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if !filepath.IsAbs(dir) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find the data directory: %w", err)
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "littime", "history.db"), nil
+}
+
+// Store — история оценок в файле bbolt
+type Store struct {
+	db *bbolt.DB
+}
+
+// Open открывает историю, создавая файл и каталог при необходимости.
+// Если файл занят другим процессом дольше секунды, возвращается ошибка.
+func Open(path string) (*Store, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create history directory: %w", err)
+	}
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history %s: %w", path, err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close закрывает файл истории
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Add сохраняет запись. Ключ записи — время и хэш текста, поэтому записи файла упорядочены по времени.
+func (s *Store) Add(entry Entry) error {
+	value, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		runs, err := tx.CreateBucketIfNotExists(runsBucket)
+		if err != nil {
+			return err
+		}
+		document, err := runs.CreateBucketIfNotExists([]byte(entry.Path))
+		if err != nil {
+			return err
+		}
+		return document.Put(entryKey(entry), value)
+	})
+}
+
+func entryKey(entry Entry) []byte {
+	key := binary.BigEndian.AppendUint64(nil, uint64(entry.Time.UnixNano()))
+	return append(key, entry.Hash...)
+}
+
+// Entries возвращает записи файла path от старых к новым; пустой path означает все файлы.
+// Если записей нет, возвращается ErrNoHistory.
+func (s *Store) Entries(path string) ([]Entry, error) {
+	var entries []Entry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		runs := tx.Bucket(runsBucket)
+		if runs == nil {
+			return nil
+		}
+		return runs.ForEachBucket(func(name []byte) error {
+			if path != "" && string(name) != path {
+				return nil
+			}
+			return runs.Bucket(name).ForEach(func(_, value []byte) error {
+				var entry Entry
+				if err := json.Unmarshal(value, &entry); err != nil {
+					return fmt.Errorf("corrupted history entry for %s: %w", name, err)
+				}
+				entries = append(entries, entry)
+				return nil
+			})
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		if path == "" {
+			return nil, ErrNoHistory
+		}
+		return nil, fmt.Errorf("%w for %s", ErrNoHistory, path)
+	}
+
+	slices.SortStableFunc(entries, func(a, b Entry) int { return a.Time.Compare(b.Time) })
+	return entries, nil
+}
+
+// Documents возвращает сводку по каждому файлу в истории, начиная с недавно оцененных
+func (s *Store) Documents() ([]Document, error) {
+	entries, err := s.Entries("")
+	if err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]*Document)
+	var documents []*Document
+	for _, entry := range entries {
+		document, ok := byPath[entry.Path]
+		if !ok {
+			document = &Document{Path: entry.Path}
+			byPath[entry.Path] = document
+			documents = append(documents, document)
+		}
+		document.Runs++
+		document.Last = entry
+	}
+
+	result := make([]Document, len(documents))
+	for i, document := range documents {
+		result[i] = *document
+	}
+	slices.SortStableFunc(result, func(a, b Document) int { return b.Last.Time.Compare(a.Last.Time) })
+	return result, nil
+}
+
+// ExportFormats возвращает форматы, в которых можно выгрузить историю
+func ExportFormats() []output.Format {
+	return []output.Format{output.FormatJSON, output.FormatNDJSON, output.FormatYAML, output.FormatCSV}
+}
+
+// Export записывает записи в w в одном из форматов ExportFormats
+func Export(w io.Writer, entries []Entry, format output.Format) error {
+	switch format {
+	case output.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(entries)
+	case output.FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := encoder.Encode(entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	case output.FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(entries); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case output.FormatCSV:
+		return writeCSV(w, entries)
+	default:
+		return fmt.Errorf("history cannot be exported as %s (supported: %s, %s, %s, %s)", format,
+			output.FormatJSON, output.FormatNDJSON, output.FormatYAML, output.FormatCSV)
+	}
+}
+
+func writeCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+	header := []string{
+		"time", "path", "hash", "profile", "mode", "reading_speed",
+		"reading_time", "word_count", "sentence_count", "syllable_count", "flesch_kincaid_index",
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		row := []string{
+			e.Time.Format(time.RFC3339), e.Path, e.Hash, e.Profile, string(e.Mode),
+			strconv.FormatFloat(e.ReadingSpeed, 'f', -1, 64),
+			strconv.FormatFloat(e.ReadingTime, 'f', 2, 64),
+			strconv.Itoa(e.WordCount), strconv.Itoa(e.SentenceCount), strconv.Itoa(e.SyllableCount),
+			e.fleschKincaid("%.2f"),
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteTrend выводит таблицу оценок одного файла с изменениями относительно предыдущей оценки
+func WriteTrend(w io.Writer, entries []Entry) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tHASH\tMODE\tREADING TIME\tFLESCH-KINCAID\tWORDS")
+	for i, e := range entries {
+		readingTime := fmt.Sprintf("%.2f min", e.ReadingTime)
+		index := e.fleschKincaid("%.1f")
+		words := strconv.Itoa(e.WordCount)
+		if i > 0 {
+			prev := entries[i-1]
+			readingTime += delta(e.ReadingTime-prev.ReadingTime, "%+.2f")
+			if !e.FleschKincaidNotApplicable && !prev.FleschKincaidNotApplicable {
+				index += delta(e.FleschKincaidIndex-prev.FleschKincaidIndex, "%+.1f")
+			}
+			words += delta(float64(e.WordCount-prev.WordCount), "%+.0f")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			e.Time.Local().Format("2006-01-02 15:04"), shortHash(e.Hash), e.Mode, readingTime, index, words)
+	}
+	return tw.Flush()
+}
+
+// WriteDocuments выводит таблицу файлов из истории с последней оценкой каждого
+func WriteDocuments(w io.Writer, documents []Document) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "LAST RUN\tRUNS\tREADING TIME\tFLESCH-KINCAID\tPATH")
+	for _, d := range documents {
+		fmt.Fprintf(tw, "%s\t%d\t%.2f min\t%s\t%s\n",
+			d.Last.Time.Local().Format("2006-01-02 15:04"), d.Runs, d.Last.ReadingTime, d.Last.fleschKincaid("%.1f"), d.Path)
+	}
+	return tw.Flush()
+}
+
+// fleschKincaid форматирует индекс читаемости; неприменимый индекс выводится как "n/a"
+func (e Entry) fleschKincaid(format string) string {
+	if e.FleschKincaidNotApplicable {
+		return "n/a"
+	}
+	return fmt.Sprintf(format, e.FleschKincaidIndex)
+}
+
+// delta форматирует изменение значения; нулевое изменение не выводится
+func delta(change float64, format string) string {
+	formatted := fmt.Sprintf(format, change)
+	if strings.Trim(formatted, "+-0.") == "" {
+		return ""
+	}
+	return " (" + formatted + ")"
+}
+
+func shortHash(hash string) string {
+	return hash[:min(8, len(hash))]
+}
@@ -0,0 +1,113 @@
+package history
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"LitTime/output"
+)
+
+func TestStore(t *testing.T) {
+	store, err := Open(filepath.Join(t.TempDir(), "data", "history.db"))
+	if err != nil {
+		t.Fatalf("Open() returned error: %v", err)
+	}
+	defer store.Close()
+
+	if _, err := store.Entries(""); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("Entries() of an empty store error = %v; want ErrNoHistory", err)
+	}
+
+	start := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Time: start.Add(2 * time.Hour), Path: "/docs/a.md", Hash: Hash("v2"), ReadingTime: 5, WordCount: 1000, FleschKincaidIndex: 60},
+		{Time: start, Path: "/docs/a.md", Hash: Hash("v1"), ReadingTime: 4, WordCount: 800, FleschKincaidIndex: 65},
+		{Time: start.Add(time.Hour), Path: "/docs/b.md", Hash: Hash("b"), ReadingTime: 1, WordCount: 200, FleschKincaidIndex: 70},
+	}
+	for _, entry := range entries {
+		if err := store.Add(entry); err != nil {
+			t.Fatalf("Add() returned error: %v", err)
+		}
+	}
+
+	a, err := store.Entries("/docs/a.md")
+	if err != nil || len(a) != 2 {
+		t.Fatalf("Entries(a.md) = %d entries, %v; want 2", len(a), err)
+	}
+	if a[0].Hash != Hash("v1") || a[1].Hash != Hash("v2") {
+		t.Errorf("Entries(a.md) are not ordered by time: %v, %v", a[0].Time, a[1].Time)
+	}
+	if _, err := store.Entries("/docs/c.md"); !errors.Is(err, ErrNoHistory) {
+		t.Errorf("Entries(c.md) error = %v; want ErrNoHistory", err)
+	}
+
+	documents, err := store.Documents()
+	if err != nil || len(documents) != 2 {
+		t.Fatalf("Documents() = %d documents, %v; want 2", len(documents), err)
+	}
+	if documents[0].Path != "/docs/a.md" || documents[0].Runs != 2 || documents[0].Last.ReadingTime != 5 {
+		t.Errorf("Documents()[0] = %+v; want a.md with 2 runs, latest first", documents[0])
+	}
+
+	var trend bytes.Buffer
+	if err := WriteTrend(&trend, a); err != nil {
+		t.Fatalf("WriteTrend() returned error: %v", err)
+	}
+	if !strings.Contains(trend.String(), "5.00 min (+1.00)") || !strings.Contains(trend.String(), "60.0 (-5.0)") {
+		t.Errorf("WriteTrend() does not show the changes:\n%s", trend.String())
+	}
+
+	var csv bytes.Buffer
+	if err := Export(&csv, a, output.FormatCSV); err != nil {
+		t.Fatalf("Export(csv) returned error: %v", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(csv.String()), "\n"); len(lines) != 3 || !strings.HasPrefix(lines[0], "time,path,hash") {
+		t.Errorf("Export(csv) =\n%s", csv.String())
+	}
+	if err := Export(&csv, a, output.FormatMarkdown); err == nil {
+		t.Error("Export(markdown) expected an error")
+	}
+}
+
+func TestExportJSON(t *testing.T) {
+	entries := []Entry{{
+		Time: time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC), Path: "/docs/a.md", Hash: Hash("a"),
+		ReadingSpeed: 200, ReadingTime: 4, WordCount: 800, SentenceCount: 40, SyllableCount: 1200, FleschKincaidIndex: 65,
+	}}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, entries, output.FormatJSON); err != nil {
+		t.Fatalf("Export(json) returned error: %v", err)
+	}
+	var exported []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &exported); err != nil || len(exported) != 1 {
+		t.Fatalf("Export(json) = %s, %v; want one entry", buf.String(), err)
+	}
+	for _, key := range []string{
+		"time", "path", "hash", "mode", "reading_speed", "reading_time",
+		"word_count", "sentence_count", "syllable_count", "flesch_kincaid_index",
+	} {
+		if _, ok := exported[0][key]; !ok {
+			t.Errorf("Export(json) has no %q key: %s", key, buf.String())
+		}
+	}
+	for _, key := range []string{"ReadingTime", "profile", "flesch_kincaid_not_applicable"} {
+		if _, ok := exported[0][key]; ok {
+			t.Errorf("Export(json) has unexpected %q key: %s", key, buf.String())
+		}
+	}
+
+	// Записи, сохраненные до появления json-тегов, по-прежнему читаются
+	var legacy Entry
+	if err := json.Unmarshal([]byte(`{"Path":"/docs/a.md","ReadingTime":4,"WordCount":800,"FleschKincaidIndex":65}`), &legacy); err != nil {
+		t.Fatalf("json.Unmarshal() of a legacy entry returned error: %v", err)
+	}
+	if legacy.Path != "/docs/a.md" || legacy.ReadingTime != 4 || legacy.WordCount != 800 || legacy.FleschKincaidIndex != 65 {
+		t.Errorf("Legacy entry = %+v; want its fields restored", legacy)
+	}
+}
@@ -2,7 +2,6 @@ package main
 
 import (
 	"fmt"
-	"log"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -12,22 +11,41 @@ import (
 )
 
 func main() {
-	cfg, err := config.LoadConfig()
-
-	if err != nil {
-		log.Fatalf("Error loading config: %v", err)
-	}
+	// Даже с ошибкой конфигурация пригодна для команд config show и config init,
+	// остальные команды получат ошибку из CheckConfig
+	cfg, cfgErr := config.LoadConfig(cmd.ConfigPath(os.Args[1:]))
 
 	rootCmd := &cobra.Command{
 		Use:   "littime",
 		Short: "LitTime - Reading Time Estimator",
 		Long:  `LitTime is a tool for estimating reading time of text documents.`,
+		// Ошибки печатаем сами, чтобы вывести их в stderr и вернуть правильный код завершения
+		SilenceErrors: true,
+		PersistentPreRunE: func(c *cobra.Command, args []string) error {
+			return cmd.CheckConfig(c, cfgErr)
+		},
 	}
+	rootCmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return &cmd.ExitError{Code: cmd.ExitUsage, Err: err}
+	})
+
+	cmd.AddConfigFlag(rootCmd)
+	cmd.AddProfileFlag(rootCmd)
 
 	rootCmd.AddCommand(cmd.NewRunCmd(cfg))
+	rootCmd.AddCommand(cmd.NewReportCmd(cfg))
+	rootCmd.AddCommand(cmd.NewDiffCmd(cfg))
+	rootCmd.AddCommand(cmd.NewGitCmd(cfg))
+	rootCmd.AddCommand(cmd.NewCheckCmd(cfg))
+	rootCmd.AddCommand(cmd.NewLintCmd(cfg))
+	rootCmd.AddCommand(cmd.NewAnnotateCmd(cfg))
+	rootCmd.AddCommand(cmd.NewCalibrateCmd(cfg))
+	rootCmd.AddCommand(cmd.NewConfigCmd(cfg, cfgErr))
+	rootCmd.AddCommand(cmd.NewHistoryCmd(cfg))
+	rootCmd.AddCommand(cmd.NewCacheCmd(cfg))
 
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
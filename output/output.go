@@ -0,0 +1,190 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+
+	"LitTime/estimator"
+)
+
+// Format определяет формат, в котором сохраняется результат
+type Format string
+
+const (
+	FormatJSON     Format = "json"
+	FormatNDJSON   Format = "ndjson"
+	FormatYAML     Format = "yaml"
+	FormatCSV      Format = "csv"
+	FormatTOML     Format = "toml"
+	FormatMarkdown Format = "markdown"
+	FormatText     Format = "text"
+)
+
+// Stdout — значение пути вывода, означающее стандартный вывод
+const Stdout = "-"
+
+var aliases = map[string]Format{
+	"json":     FormatJSON,
+	"ndjson":   FormatNDJSON,
+	"jsonl":    FormatNDJSON,
+	"yaml":     FormatYAML,
+	"yml":      FormatYAML,
+	"csv":      FormatCSV,
+	"toml":     FormatTOML,
+	"markdown": FormatMarkdown,
+	"md":       FormatMarkdown,
+	"text":     FormatText,
+	"txt":      FormatText,
+}
+
+// Formats возвращает список поддерживаемых форматов
+func Formats() []string {
+	return []string{
+		string(FormatJSON), string(FormatNDJSON), string(FormatYAML), string(FormatCSV),
+		string(FormatTOML), string(FormatMarkdown), string(FormatText),
+	}
+}
+
+// ParseFormat разбирает название формата с учетом синонимов (yml, md, txt, jsonl)
+func ParseFormat(name string) (Format, error) {
+	format, ok := aliases[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return "", fmt.Errorf("unknown output format %q (supported: %s)", name, strings.Join(Formats(), ", "))
+	}
+	return format, nil
+}
+
+// field — одна строка сводки для табличных и текстовых форматов
+type field struct {
+	key   string
+	label string
+	value string
+}
+
+// summary возвращает основные показатели результата в фиксированном порядке
+func summary(result *estimator.Result) []field {
+	return []field{
+		{"reading_time", "Reading time", strconv.FormatFloat(result.ReadingTime, 'f', 2, 64)},
+		{"word_count", "Words", strconv.Itoa(result.WordCount)},
+		{"sentence_count", "Sentences", strconv.Itoa(result.SentenceCount)},
+		{"syllable_count", "Syllables", strconv.Itoa(result.SyllableCount)},
+		{"flesch_kincaid_index", "Flesch-Kincaid Index", fleschKincaid(result)},
+	}
+}
+
+// fleschKincaid форматирует индекс читаемости; неприменимый индекс выводится как "n/a"
+func fleschKincaid(result *estimator.Result) string {
+	if result.FleschKincaidNotApplicable {
+		return "n/a"
+	}
+	return strconv.FormatFloat(result.FleschKincaidIndex, 'f', 2, 64)
+}
+
+// Write записывает результат в w в заданном формате
+func Write(w io.Writer, result *estimator.Result, format Format) error {
+	switch format {
+	case FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case FormatNDJSON:
+		return json.NewEncoder(w).Encode(result)
+	case FormatYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(result); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case FormatTOML:
+		return toml.NewEncoder(w).Encode(result)
+	case FormatCSV:
+		return writeCSV(w, result)
+	case FormatMarkdown:
+		return writeMarkdown(w, result)
+	case FormatText:
+		return writeText(w, result)
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// WriteFile записывает результат в файл или в стандартный вывод, если path равен "-"
+func WriteFile(path string, result *estimator.Result, format Format) error {
+	if path == Stdout {
+		return Write(os.Stdout, result, format)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := Write(file, result, format); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// Summary возвращает краткую однострочную сводку для неинтерактивного режима
+func Summary(name string, result *estimator.Result) string {
+	return fmt.Sprintf("%s: %.2f min read (%d words, %d sentences, Flesch-Kincaid %s)",
+		name, result.ReadingTime, result.WordCount, result.SentenceCount, fleschKincaid(result))
+}
+
+func writeCSV(w io.Writer, result *estimator.Result) error {
+	fields := summary(result)
+	header := make([]string, len(fields))
+	row := make([]string, len(fields))
+	for i, f := range fields {
+		header[i] = f.key
+		row[i] = f.value
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.Write(row); err != nil {
+		return err
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeMarkdown(w io.Writer, result *estimator.Result) error {
+	var b strings.Builder
+	b.WriteString("| Metric | Value |\n")
+	b.WriteString("| --- | ---: |\n")
+	for _, f := range summary(result) {
+		value := f.value
+		if f.key == "reading_time" {
+			value += " min"
+		}
+		fmt.Fprintf(&b, "| %s | %s |\n", f.label, value)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeText(w io.Writer, result *estimator.Result) error {
+	var b strings.Builder
+	for _, f := range summary(result) {
+		value := f.value
+		if f.key == "reading_time" {
+			value += " min"
+		}
+		fmt.Fprintf(&b, "%s: %s\n", f.label, value)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
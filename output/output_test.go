@@ -0,0 +1,89 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"LitTime/estimator"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    Format
+		expectError bool
+	}{
+		{"json", FormatJSON, false},
+		{"JSONL", FormatNDJSON, false},
+		{"yml", FormatYAML, false},
+		{"md", FormatMarkdown, false},
+		{" txt ", FormatText, false},
+		{"xml", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			format, err := ParseFormat(test.name)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("ParseFormat(%q) expected an error, got %q", test.name, format)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseFormat(%q) returned an unexpected error: %v", test.name, err)
+			}
+			if format != test.expected {
+				t.Errorf("ParseFormat(%q) = %q; want %q", test.name, format, test.expected)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	result := &estimator.Result{
+		ReadingTime:        1.5,
+		WordCount:          300,
+		SentenceCount:      20,
+		SyllableCount:      450,
+		FleschKincaidIndex: 72.25,
+	}
+
+	tests := []struct {
+		format   Format
+		contains []string
+	}{
+		{FormatJSON, []string{`"reading_time": 1.5`, `"word_count": 300`}},
+		{FormatNDJSON, []string{`{"reading_time":1.5,"word_count":300,`}},
+		{FormatYAML, []string{"reading_time: 1.5", "word_count: 300"}},
+		{FormatTOML, []string{"reading_time = 1.5", "word_count = 300"}},
+		{FormatCSV, []string{"reading_time,word_count,sentence_count,syllable_count,flesch_kincaid_index\n1.50,300,20,450,72.25\n"}},
+		{FormatMarkdown, []string{"| Metric | Value |", "| Reading time | 1.50 min |"}},
+		{FormatText, []string{"Reading time: 1.50 min\n", "Flesch-Kincaid Index: 72.25\n"}},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, result, test.format); err != nil {
+				t.Fatalf("Write() returned an unexpected error: %v", err)
+			}
+			for _, s := range test.contains {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("Write(%s) output %q does not contain %q", test.format, buf.String(), s)
+				}
+			}
+		})
+	}
+
+	t.Run("ndjson is a single line", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := Write(&buf, result, FormatNDJSON); err != nil {
+			t.Fatalf("Write() returned an unexpected error: %v", err)
+		}
+		if strings.Count(buf.String(), "\n") != 1 {
+			t.Errorf("NDJSON output must be exactly one line, got %q", buf.String())
+		}
+	})
+}
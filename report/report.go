@@ -0,0 +1,171 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+
+	"LitTime/estimator"
+)
+
+//go:embed report.html.tmpl
+var pageTemplate string
+
+var tmpl = template.Must(template.New("report").Parse(pageTemplate))
+
+// Options задает параметры HTML-отчета
+type Options struct {
+	Title    string            // Название документа, обычно путь к файлу
+	Hardest  int               // Сколько самых сложных предложений выделить
+	Estimate estimator.Options // Параметры, с которыми анализируются абзацы и предложения
+}
+
+type page struct {
+	Title      string
+	Result     *estimator.Result
+	Gauges     []gauge
+	Paragraphs []paragraph
+	Hardest    []hardSentence
+	Modes      []modeTime
+}
+
+// modeTime — время чтения в одной из моделей для сравнения
+type modeTime struct {
+	Mode    estimator.ReadingMode
+	Minutes float64
+}
+
+type gauge struct {
+	Label   string
+	Value   string
+	Hint    string
+	Percent int // Заполнение шкалы, 0-100
+	Hue     int // Цвет шкалы: 0 — красный (плохо), 120 — зеленый (хорошо)
+}
+
+type paragraph struct {
+	Number   int
+	Stats    estimator.PassageStats
+	Hue      int
+	Segments []segment
+}
+
+// segment — часть абзаца; Rank > 0 у предложений из списка самых сложных
+type segment struct {
+	Text string
+	Rank int
+}
+
+type hardSentence struct {
+	Rank  int
+	Stats estimator.PassageStats
+}
+
+// Write формирует самодостаточный HTML-отчет (без внешних стилей и скриптов) и записывает его в w
+func Write(w io.Writer, text string, result *estimator.Result, opts Options) error {
+	if result == nil {
+		return fmt.Errorf("report requires an estimation result")
+	}
+
+	paragraphs, sentences, err := estimator.AnalyzeStructure(text, opts.Estimate)
+	if err != nil {
+		return err
+	}
+
+	ranks := make(map[int]int)
+	var hardest []hardSentence
+	for i, s := range estimator.HardestSentences(sentences, opts.Hardest) {
+		hardest = append(hardest, hardSentence{Rank: i + 1, Stats: s})
+		ranks[s.Start] = i + 1
+	}
+
+	data := page{
+		Title:   opts.Title,
+		Result:  result,
+		Gauges:  gauges(result),
+		Hardest: hardest,
+	}
+	for _, mode := range estimator.ReadingModes() {
+		if minutes, ok := result.ModeTimes[mode]; ok {
+			data.Modes = append(data.Modes, modeTime{Mode: mode, Minutes: minutes})
+		}
+	}
+	for _, p := range paragraphs {
+		data.Paragraphs = append(data.Paragraphs, paragraph{
+			Number:   p.Paragraph,
+			Stats:    p,
+			Hue:      heatHue(p.FleschKincaidIndex),
+			Segments: segments(text, p, ranks),
+		})
+	}
+
+	return tmpl.Execute(w, data)
+}
+
+// segments разбивает абзац на куски так, чтобы сложные предложения можно было выделить
+func segments(text string, p estimator.PassageStats, ranks map[int]int) []segment {
+	var result []segment
+	pos := p.Start
+	for _, span := range estimator.SplitSentences(p.Text) {
+		start, end := p.Start+span.Start, p.Start+span.End
+		rank, ok := ranks[start]
+		if !ok {
+			continue
+		}
+		if pos < start {
+			result = append(result, segment{Text: text[pos:start]})
+		}
+		result = append(result, segment{Text: text[start:end], Rank: rank})
+		pos = end
+	}
+	if pos < p.End {
+		result = append(result, segment{Text: text[pos:p.End]})
+	}
+	return result
+}
+
+func gauges(result *estimator.Result) []gauge {
+	wordsPerSentence := float64(result.WordCount) / math.Max(float64(result.SentenceCount), 1)
+	syllablesPerWord := float64(result.SyllableCount) / math.Max(float64(result.WordCount), 1)
+
+	var list []gauge
+	// Неприменимый индекс (например, у китайского текста) не показываем, чтобы 0 не выглядел оценкой
+	if !result.FleschKincaidNotApplicable {
+		list = append(list, gauge{
+			Label:   "Flesch-Kincaid Index",
+			Value:   fmt.Sprintf("%.1f", result.FleschKincaidIndex),
+			Hint:    "higher is easier",
+			Percent: percent(result.FleschKincaidIndex, 0, 100),
+			Hue:     heatHue(result.FleschKincaidIndex),
+		})
+	}
+	return append(list, []gauge{
+		{
+			Label:   "Words per sentence",
+			Value:   fmt.Sprintf("%.1f", wordsPerSentence),
+			Hint:    "15-20 is comfortable",
+			Percent: percent(wordsPerSentence, 0, 40),
+			Hue:     120 - percent(wordsPerSentence, 10, 35)*120/100,
+		},
+		{
+			Label:   "Syllables per word",
+			Value:   fmt.Sprintf("%.2f", syllablesPerWord),
+			Hint:    "lower is easier",
+			Percent: percent(syllablesPerWord, 1, 4),
+			Hue:     120 - percent(syllablesPerWord, 1.3, 3)*120/100,
+		},
+	}...)
+}
+
+// percent переводит значение из диапазона [low, high] в проценты с ограничением 0-100
+func percent(value, low, high float64) int {
+	p := (value - low) / (high - low) * 100
+	return int(math.Round(math.Max(0, math.Min(100, p))))
+}
+
+// heatHue переводит индекс читаемости в оттенок: сложный текст — красный, простой — зеленый
+func heatHue(fkIndex float64) int {
+	return percent(fkIndex, 0, 100) * 120 / 100
+}
@@ -0,0 +1,68 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"LitTime/estimator"
+)
+
+const reportText = "The cat sat on the mat. It was a sunny day.\n\n" +
+	"Notwithstanding considerable institutional opposition, the <script>administration</script> implemented restructuring."
+
+func writeReport(t *testing.T, opts Options) string {
+	t.Helper()
+
+	opts.Estimate = estimator.Options{ReadingSpeed: 200}
+	result, err := estimator.Estimate(reportText, estimator.Options{ReadingSpeed: 200, Workers: 1})
+	if err != nil {
+		t.Fatalf("Estimate() returned an unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, reportText, &result, opts); err != nil {
+		t.Fatalf("Write() returned an unexpected error: %v", err)
+	}
+	return buf.String()
+}
+
+func TestWrite(t *testing.T) {
+	html := writeReport(t, Options{Title: "docs/<draft>.md", Hardest: 1})
+
+	for _, want := range []string{
+		"<title>LitTime report — docs/&lt;draft&gt;.md</title>",
+		"<h2>Readability</h2>", "Flesch-Kincaid Index", "Words per sentence",
+		"<h2>Hardest sentences</h2>", "<mark><sup>1</sup>Notwithstanding",
+		"<h2>Text heatmap</h2>", `id="p1"`, `id="p2"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("Write() output does not contain %q", want)
+		}
+	}
+
+	// Текст документа не должен попадать в отчет как разметка
+	if strings.Contains(html, "<script>administration") {
+		t.Error("Write() does not escape the document text")
+	}
+	if !strings.Contains(html, "&lt;script&gt;administration&lt;/script&gt;") {
+		t.Error("Write() output does not contain the escaped sentence text")
+	}
+}
+
+func TestWriteWithoutHardest(t *testing.T) {
+	html := writeReport(t, Options{})
+
+	if strings.Contains(html, "Hardest sentences") || strings.Contains(html, "<mark>") {
+		t.Error("Write() without Hardest must not list or highlight hardest sentences")
+	}
+	if !strings.Contains(html, "<h2>Readability</h2>") || !strings.Contains(html, "sunny day") {
+		t.Error("Write() without Hardest must still render the readability and the text")
+	}
+}
+
+func TestWriteWithoutResult(t *testing.T) {
+	if err := Write(&bytes.Buffer{}, reportText, nil, Options{}); err == nil {
+		t.Error("Write() expected an error without a result")
+	}
+}
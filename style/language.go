@@ -0,0 +1,136 @@
+package style
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"LitTime/estimator"
+)
+
+var (
+	// Формы глагола to be, после которых причастие образует страдательный залог
+	beForms = newWordSet("am", "is", "are", "was", "were", "be", "been", "being", "isn't", "aren't", "wasn't", "weren't")
+
+	// Неправильные причастия прошедшего времени; правильные оканчиваются на -ed
+	irregularParticiples = newWordSet(
+		"begun", "bought", "brought", "built", "caught", "chosen", "done", "drawn", "driven", "eaten", "fallen",
+		"felt", "forgotten", "found", "given", "grown", "heard", "held", "hidden", "kept", "known", "laid", "led",
+		"left", "lost", "made", "meant", "paid", "put", "read", "said", "seen", "sent", "set", "shown", "sold",
+		"spoken", "spent", "stolen", "taken", "taught", "thought", "thrown", "told", "torn", "understood", "won",
+		"worn", "written",
+	)
+
+	// Слова на -ed, которые не бывают причастием страдательного залога
+	notParticiples = newWordSet("bed", "red", "shed", "need", "seed", "speed", "feed", "indeed", "hundred", "sacred", "naked", "wicked", "tired")
+
+	// Русские страдательные причастия: полные на -нный и -мый, краткие на -н
+	russianParticipleRegex      = regexp.MustCompile(`^[а-яё]{2,}(?:(?:анн|янн|енн|ённ)(?:ый|ая|ое|ые|ого|ой|ому|ым|ых|ую|ыми)|(?:ем|им)(?:ый|ая|ое|ые|ого|ому|ым|ых|ыми))$`)
+	russianShortParticipleRegex = regexp.MustCompile(`^[а-яё]{2,}(?:ан|ян|ен|ён)[аоы]?$`)
+
+	// Краткое причастие без глагола-связки отличаем от существительного ("стакан") по глагольной приставке
+	russianVerbPrefixRegex = regexp.MustCompile(`^(?:пере|про|при|под|раз|рас|без|бес|вы|за|на|по|от|об|из|ис|до|вз|вс|со)`)
+	russianBeForms         = newWordSet("был", "была", "было", "были", "будет", "будут", "будем", "будешь", "буду", "будете")
+
+	// Прилагательные и краткие прилагательные с теми же окончаниями
+	russianAdjectiveRegex = regexp.MustCompile(`(?:ственн|менн|ценн|ленн|странн|туманн|карманн|деревянн|стеклянн|оловянн)|^(?:долж|нуж|соглас|способ|рав|бол|довол|уверен|откровен|необходим|невыносим|любим|одержим|неотъемлем|значим)`)
+
+	// Наречия-усилители, которые почти всегда можно убрать
+	intensifiers = newWordSet(
+		"very", "really", "quite", "rather", "too", "extremely", "totally", "absolutely", "literally", "actually",
+		"очень", "слишком", "весьма", "крайне", "довольно", "совсем", "абсолютно", "практически", "буквально", "действительно", "вполне",
+	)
+
+	// Слова на -ly, которые не являются наречиями
+	notAdverbs = newWordSet(
+		"only", "family", "early", "reply", "supply", "apply", "imply", "comply", "multiply", "rely", "ally", "italy",
+		"july", "belly", "bully", "holy", "ugly", "silly", "jelly", "lily", "rally", "folly", "assembly", "anomaly",
+		"monopoly", "butterfly", "lovely", "friendly", "lonely", "costly", "elderly", "orderly", "deadly", "daily",
+		"weekly", "monthly", "yearly", "hourly", "likely", "curly", "jolly", "ghastly", "scholarly",
+	)
+
+	// Суффиксы русских наречий образа действия
+	russianAdverbSuffixes = []string{"ально", "ельно", "ично", "енно", "ески", "ьски", "ательно", "ивно"}
+)
+
+// passiveConstructions находит страдательный залог в словах предложения:
+// в английском — форму to be и причастие, между которыми может стоять наречие,
+// в русском — страдательное причастие. Возвращает фрагменты с найденными конструкциями.
+func passiveConstructions(words []estimator.Token) []estimator.Span {
+	var spans []estimator.Span
+	for i := 0; i < len(words); i++ {
+		word := strings.ToLower(words[i].Text)
+		if words[i].Class != estimator.TokenWord {
+			continue
+		}
+
+		if beForms[word] {
+			j := i + 1
+			if j < len(words) && isEnglishAdverb(strings.ToLower(words[j].Text)) {
+				j++
+			}
+			if j < len(words) && words[j].Class == estimator.TokenWord && isEnglishParticiple(strings.ToLower(words[j].Text)) {
+				spans = append(spans, estimator.Span{Start: words[i].Start, End: words[j].End})
+				i = j
+			}
+			continue
+		}
+
+		// Имя собственное внутри предложения причастием не бывает
+		if i > 0 && startsUpper(words[i].Text) {
+			continue
+		}
+		afterBe := i > 0 && russianBeForms[strings.ToLower(words[i-1].Text)]
+		if isRussianParticiple(word, afterBe) {
+			spans = append(spans, estimator.Span{Start: words[i].Start, End: words[i].End})
+		}
+	}
+	return spans
+}
+
+func isEnglishParticiple(word string) bool {
+	if irregularParticiples[word] {
+		return true
+	}
+	return strings.HasSuffix(word, "ed") && utf8.RuneCountInString(word) > 3 && !notParticiples[word]
+}
+
+// isRussianParticiple определяет страдательное причастие; afterBe — слово стоит после формы глагола быть
+func isRussianParticiple(word string, afterBe bool) bool {
+	if utf8.RuneCountInString(word) < 5 || russianAdjectiveRegex.MatchString(word) {
+		return false
+	}
+	if russianParticipleRegex.MatchString(word) {
+		return true
+	}
+	return russianShortParticipleRegex.MatchString(word) && (afterBe || russianVerbPrefixRegex.MatchString(word))
+}
+
+// isAdverb определяет наречия, которые обычно ослабляют текст: усилители и наречия образа действия
+func isAdverb(word string) bool {
+	if intensifiers[word] {
+		return true
+	}
+	if isEnglishAdverb(word) {
+		return true
+	}
+	if utf8.RuneCountInString(word) < 6 {
+		return false
+	}
+	for _, suffix := range russianAdverbSuffixes {
+		if strings.HasSuffix(word, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEnglishAdverb(word string) bool {
+	return strings.HasSuffix(word, "ly") && utf8.RuneCountInString(word) > 4 && !notAdverbs[word]
+}
+
+func startsUpper(word string) bool {
+	r, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsUpper(r)
+}
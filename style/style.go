@@ -0,0 +1,244 @@
+package style
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"LitTime/estimator"
+)
+
+// Kind — вид замечания о стиле
+type Kind string
+
+const (
+	KindLongSentence Kind = "long-sentence"
+	KindComplexWord  Kind = "complex-word"
+	KindPassive      Kind = "passive-voice"
+	KindAdverbs      Kind = "adverbs"
+	KindRepeated     Kind = "repeated-word"
+)
+
+// Kinds возвращает все виды замечаний в порядке вывода
+func Kinds() []Kind {
+	return []Kind{KindLongSentence, KindComplexWord, KindPassive, KindAdverbs, KindRepeated}
+}
+
+// Description возвращает описание вида замечаний
+func (k Kind) Description() string {
+	switch k {
+	case KindLongSentence:
+		return "Sentence is too long to read easily"
+	case KindComplexWord:
+		return "Word has many syllables, a simpler word may do"
+	case KindPassive:
+		return "Passive voice hides who does the action"
+	case KindAdverbs:
+		return "Too many adverbs in a paragraph"
+	case KindRepeated:
+		return "Word is repeated too close to its previous use"
+	}
+	return string(k)
+}
+
+// ParseKind разбирает название вида замечаний
+func ParseKind(name string) (Kind, error) {
+	kind := Kind(strings.ToLower(strings.TrimSpace(name)))
+	if !slices.Contains(Kinds(), kind) {
+		kinds := make([]string, 0, len(Kinds()))
+		for _, k := range Kinds() {
+			kinds = append(kinds, string(k))
+		}
+		return "", fmt.Errorf("unknown style check %q (supported: %s)", name, strings.Join(kinds, ", "))
+	}
+	return kind, nil
+}
+
+// Options — пороги анализа стиля
+type Options struct {
+	MaxSentenceWords int     // Предложение длиннее — long-sentence
+	MaxSyllables     int     // Слово с большим числом слогов — complex-word
+	MaxAdverbShare   float64 // Доля наречий в абзаце, выше которой — adverbs
+	RepeatWindow     int     // Сколько предыдущих слов просматривается в поисках повтора
+	Syllables        estimator.SyllableBackend
+	Disabled         []Kind
+}
+
+// DefaultOptions возвращает пороги по умолчанию; они близки к рекомендациям редакторов вроде Hemingway
+func DefaultOptions() Options {
+	return Options{
+		MaxSentenceWords: 25,
+		MaxSyllables:     5,
+		MaxAdverbShare:   0.05,
+		RepeatWindow:     20,
+		Syllables:        estimator.SyllablesHeuristic,
+	}
+}
+
+// Validate проверяет пороги
+func (o Options) Validate() error {
+	switch {
+	case o.MaxSentenceWords < 1:
+		return fmt.Errorf("maximum sentence length must be at least 1, got %d", o.MaxSentenceWords)
+	case o.MaxSyllables < 1:
+		return fmt.Errorf("maximum syllables must be at least 1, got %d", o.MaxSyllables)
+	case o.MaxAdverbShare < 0 || o.MaxAdverbShare > 1:
+		return fmt.Errorf("adverb share must be between 0 and 1, got %g", o.MaxAdverbShare)
+	case o.RepeatWindow < 0:
+		return fmt.Errorf("repeat window must not be negative, got %d", o.RepeatWindow)
+	}
+	_, err := estimator.ParseSyllableBackend(string(o.Syllables))
+	return err
+}
+
+func (o Options) enabled(kind Kind) bool {
+	return !slices.Contains(o.Disabled, kind)
+}
+
+// Issue — замечание о фрагменте текста. Start и End — байтовые смещения в тексте,
+// Line и Column — положение начала фрагмента.
+type Issue struct {
+	Kind    Kind   `yaml:"kind"`
+	Start   int    `yaml:"start"`
+	End     int    `yaml:"end"`
+	Line    int    `yaml:"line"`
+	Column  int    `yaml:"column"`
+	Text    string `yaml:"text"`
+	Message string `yaml:"message"`
+}
+
+// Analyze ищет в тексте длинные предложения, сложные слова, страдательный залог,
+// избыток наречий и близкие повторы слов. Замечания упорядочены по положению в тексте.
+func Analyze(text string, opts Options) []Issue {
+	var issues []Issue
+	add := func(kind Kind, start, end int, format string, args ...any) {
+		if !opts.enabled(kind) {
+			return
+		}
+		line, column := estimator.Position(text, start)
+		issues = append(issues, Issue{
+			Kind: kind, Start: start, End: end, Line: line, Column: column,
+			Text: text[start:end], Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	for _, paragraph := range estimator.SplitParagraphs(text) {
+		var words []estimator.Token // Слова абзаца для поиска повторов и наречий
+		for _, sentence := range estimator.SplitSentences(paragraph.Text) {
+			start := paragraph.Start + sentence.Start
+			tokens := wordTokens(estimator.Tokenize(sentence.Text), start)
+
+			if len(tokens) > opts.MaxSentenceWords {
+				add(KindLongSentence, start, paragraph.Start+sentence.End,
+					"sentence has %d words, more than %d; consider splitting it", len(tokens), opts.MaxSentenceWords)
+			}
+			for _, token := range tokens {
+				if token.Class != estimator.TokenWord {
+					continue
+				}
+				// Части слова через дефис ("кое-как", "well-known") читаются как отдельные слова
+				partStart := token.Start
+				for _, part := range strings.Split(token.Text, "-") {
+					if syllables := estimator.CountSyllablesWith(part, opts.Syllables); syllables > opts.MaxSyllables {
+						add(KindComplexWord, partStart, partStart+len(part), "%q has %d syllables", part, syllables)
+					}
+					partStart += len(part) + 1
+				}
+			}
+			for _, span := range passiveConstructions(tokens) {
+				add(KindPassive, span.Start, span.End, "passive voice: %q", text[span.Start:span.End])
+			}
+			words = append(words, tokens...)
+		}
+
+		if opts.MaxAdverbShare > 0 {
+			var adverbs []string
+			for _, word := range words {
+				if word.Class == estimator.TokenWord && isAdverb(strings.ToLower(word.Text)) {
+					adverbs = append(adverbs, word.Text)
+				}
+			}
+			if share := float64(len(adverbs)) / float64(max(len(words), 1)); len(adverbs) > 1 && share > opts.MaxAdverbShare {
+				add(KindAdverbs, paragraph.Start, paragraph.End, "paragraph has %d adverbs in %d words (%.0f%%): %s",
+					len(adverbs), len(words), share*100, strings.Join(adverbs, ", "))
+			}
+		}
+
+		for _, repeat := range repeatedWords(words, opts.RepeatWindow) {
+			add(KindRepeated, repeat.token.Start, repeat.token.End, "%q repeats a word used %d words earlier", repeat.token.Text, repeat.distance)
+		}
+	}
+
+	slices.SortStableFunc(issues, func(a, b Issue) int { return a.Start - b.Start })
+	return issues
+}
+
+// wordTokens оставляет токены, которые читаются как слова, и переводит их смещения в смещения текста
+func wordTokens(tokens []estimator.Token, offset int) []estimator.Token {
+	words := tokens[:0]
+	for _, token := range tokens {
+		if token.Class == estimator.TokenEmoji {
+			continue
+		}
+		token.Start += offset
+		token.End += offset
+		words = append(words, token)
+	}
+	return words
+}
+
+type repeat struct {
+	token    estimator.Token
+	distance int
+}
+
+// repeatedWords ищет слова, которые уже встречались не дальше window слов назад; формы одного слова
+// сравниваются по основе. Короткие и служебные слова повторяются естественно, их повтор отмечается,
+// только если слово удвоено подряд.
+func repeatedWords(words []estimator.Token, window int) []repeat {
+	var repeats []repeat
+	last := make(map[string]int)
+	for i, word := range words {
+		if word.Class != estimator.TokenWord {
+			continue
+		}
+		key := estimator.Stem(word.Text)
+		if j, ok := last[key]; ok {
+			distance := i - j
+			if distance == 1 || (distance <= window && isContentWord(strings.ToLower(word.Text))) {
+				repeats = append(repeats, repeat{token: word, distance: distance})
+			}
+		}
+		last[key] = i
+	}
+	return repeats
+}
+
+// Слова, повтор которых не считается стилистической ошибкой
+var functionWords = newWordSet(
+	// Английские
+	"that", "this", "with", "from", "have", "they", "them", "their", "there", "which", "what", "when",
+	"where", "will", "would", "could", "should", "been", "were", "your", "into", "than", "then", "more",
+	"most", "some", "such", "only", "also", "each", "other", "these", "those",
+	// Русские
+	"этот", "если", "когда", "чтобы", "только", "который", "которая", "которое", "которые", "также",
+	"тоже", "через", "между", "после", "более", "была", "было", "были", "быть", "есть",
+)
+
+func newWordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
+func isContentWord(word string) bool {
+	return utf8.RuneCountInString(word) >= 4 && !functionWords[word] && !isNumeric(word)
+}
+
+func isNumeric(word string) bool {
+	return strings.IndexFunc(word, unicode.IsLetter) < 0
+}
@@ -0,0 +1,63 @@
+package style
+
+import (
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Kind
+	}{
+		{"plain", "The cat sat on the mat.", nil},
+		{"long sentence", "One two three four five six seven eight.", []Kind{KindLongSentence}},
+		{"complex word", "What incomprehensibility!", []Kind{KindComplexWord}},
+		{"english passive", "The report was written by the team.", []Kind{KindPassive}},
+		{"english passive with adverb", "The bug is quickly fixed.", []Kind{KindPassive}},
+		{"russian passive", "Дом был построен за год.", []Kind{KindPassive}},
+		{"russian full participle", "Письмо, написанное вчера, потеряли.", []Kind{KindPassive}},
+		{"russian noun is not a participle", "На столе стоит стакан.", nil},
+		{"adverbs", "He slowly and carefully opened the door.", []Kind{KindAdverbs}},
+		{"doubled word", "It is the the best.", []Kind{KindRepeated}},
+		{"repeated word", "Project goals matter. Every project needs goals.", []Kind{KindRepeated, KindRepeated}},
+		{"repeated word form", "The project matters. Projects need goals.", []Kind{KindRepeated}},
+		{"repeated russian word form", "Проект важен. Каждому проекту нужны цели.", []Kind{KindRepeated}},
+		{"repeated function word", "This is what it is.", nil},
+	}
+
+	opts := DefaultOptions()
+	opts.MaxSentenceWords = 7
+	opts.MaxSyllables = 5
+	opts.MaxAdverbShare = 0.1
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []Kind
+			for _, issue := range Analyze(test.text, opts) {
+				got = append(got, issue.Kind)
+			}
+			if len(got) != len(test.want) {
+				t.Fatalf("Analyze(%q) = %v, want %v", test.text, got, test.want)
+			}
+			for i := range got {
+				if got[i] != test.want[i] {
+					t.Fatalf("Analyze(%q) = %v, want %v", test.text, got, test.want)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyzePositions(t *testing.T) {
+	text := "First line.\n\nThe file was deleted."
+	opts := DefaultOptions()
+	opts.Disabled = []Kind{KindRepeated}
+
+	issues := Analyze(text, opts)
+	if len(issues) != 1 {
+		t.Fatalf("got %d issues, want 1: %+v", len(issues), issues)
+	}
+	if issue := issues[0]; issue.Line != 3 || issue.Column != 10 || issue.Text != "was deleted" {
+		t.Errorf("issue at %d:%d %q, want 3:10 \"was deleted\"", issue.Line, issue.Column, issue.Text)
+	}
+}
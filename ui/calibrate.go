@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"LitTime/calibration"
+)
+
+// ErrCalibrationAborted возвращается, если пользователь прервал калибровку
+var ErrCalibrationAborted = errors.New("calibration aborted")
+
+// Этапы калибровки для каждого текста: подготовка, чтение, вопросы
+type calibrationStage int
+
+const (
+	stageReady calibrationStage = iota
+	stageReading
+	stageQuestions
+)
+
+var passageStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+
+type calibrateModel struct {
+	passages []calibration.Passage
+	readings []calibration.Reading
+
+	current  int // Номер текущего текста
+	stage    calibrationStage
+	started  time.Time
+	question int // Номер текущего вопроса
+	choice   int // Выбранный вариант ответа
+	width    int
+	aborted  bool
+}
+
+// RunCalibration показывает тексты, замеряет время их чтения и задает вопросы на понимание
+func RunCalibration(passages []calibration.Passage) ([]calibration.Reading, error) {
+	p := tea.NewProgram(calibrateModel{passages: passages, width: 80}, tea.WithAltScreen())
+	finishedModel, err := p.Run()
+	if err != nil {
+		return nil, err
+	}
+
+	finalModel := finishedModel.(calibrateModel)
+	if finalModel.aborted {
+		return nil, ErrCalibrationAborted
+	}
+	return finalModel.readings, nil
+}
+
+func (m calibrateModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m calibrateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "esc" {
+			m.aborted = true
+			return m, tea.Quit
+		}
+		return m.handleKey(msg.String())
+	}
+	return m, nil
+}
+
+func (m calibrateModel) handleKey(key string) (tea.Model, tea.Cmd) {
+	passage := m.passages[m.current]
+
+	switch m.stage {
+	case stageReady:
+		if key == "enter" || key == " " {
+			m.stage = stageReading
+			m.started = time.Now()
+		}
+
+	case stageReading:
+		if key == "enter" {
+			m.readings = append(m.readings, calibration.Reading{Passage: passage, Duration: time.Since(m.started)})
+			m.stage, m.question, m.choice = stageQuestions, 0, 0
+		}
+
+	case stageQuestions:
+		choices := passage.Questions[m.question].Choices
+		switch key {
+		case "up", "k":
+			m.choice = (m.choice + len(choices) - 1) % len(choices)
+		case "down", "j":
+			m.choice = (m.choice + 1) % len(choices)
+		case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if n := int(key[0] - '1'); n < len(choices) {
+				m.choice = n
+				return m.answer()
+			}
+		case "enter":
+			return m.answer()
+		}
+	}
+	return m, nil
+}
+
+// answer засчитывает выбранный вариант и переходит к следующему вопросу или тексту
+func (m calibrateModel) answer() (tea.Model, tea.Cmd) {
+	passage := m.passages[m.current]
+	if m.choice == passage.Questions[m.question].Answer {
+		m.readings[len(m.readings)-1].Correct++
+	}
+
+	m.question++
+	m.choice = 0
+	if m.question < len(passage.Questions) {
+		return m, nil
+	}
+
+	m.current++
+	m.stage = stageReady
+	if m.current == len(m.passages) {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m calibrateModel) View() string {
+	if m.current >= len(m.passages) {
+		return ""
+	}
+	passage := m.passages[m.current]
+	textWidth := min(max(m.width-6, 20), 80)
+
+	var b strings.Builder
+	b.WriteString(titleStyleIter.Render(fmt.Sprintf("Calibration %d/%d · %s · %s", m.current+1, len(m.passages), passage.Language, passage.Band)))
+	b.WriteString("\n\n")
+
+	switch m.stage {
+	case stageReady:
+		b.WriteString(passageStyle.Width(textWidth).Render(fmt.Sprintf(
+			"Next: %q (%d words). Read it at your usual pace, then answer %d questions about it.",
+			passage.Title, passage.Words(), len(passage.Questions))))
+		b.WriteString("\n\n")
+		b.WriteString(dimStyle.Render("enter: start reading • esc: quit"))
+
+	case stageReading:
+		b.WriteString(highlightStyle.Render(passage.Title))
+		b.WriteString("\n\n")
+		b.WriteString(passageStyle.Width(textWidth).Render(strings.TrimSpace(passage.Text)))
+		b.WriteString("\n\n")
+		b.WriteString(dimStyle.Render("enter: done reading • esc: quit"))
+
+	case stageQuestions:
+		q := passage.Questions[m.question]
+		b.WriteString(infoStyle.Render(fmt.Sprintf("Question %d/%d", m.question+1, len(passage.Questions))))
+		b.WriteString("\n")
+		b.WriteString(passageStyle.Width(textWidth).Render(q.Prompt))
+		b.WriteString("\n\n")
+		for i, choice := range q.Choices {
+			line := fmt.Sprintf("%d. %s", i+1, choice)
+			if i == m.choice {
+				b.WriteString(focusedStyle.Render("> " + line))
+			} else {
+				b.WriteString(blurredStyle.Render("  " + line))
+			}
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render("↑/↓ or 1-9: choose • enter: answer • esc: quit"))
+	}
+
+	return appStyle.Render(b.String())
+}
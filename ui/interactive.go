@@ -2,13 +2,20 @@ package ui
 
 import (
 	"LitTime/config"
+	"LitTime/estimator"
+	"errors"
+	"fmt"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"os"
 	"strconv"
 	"strings"
 )
 
+// ErrInteractiveAborted возвращается, если пользователь закрыл форму, не отправив ее
+var ErrInteractiveAborted = errors.New("interactive setup aborted")
+
 var (
 	appStyle = lipgloss.NewStyle().Margin(1, 2)
 
@@ -22,6 +29,7 @@ var (
 	focusedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4"))
 	blurredStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888"))
 	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+	errorStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5F87")).PaddingLeft(2)
 )
 
 type userInputs struct {
@@ -33,7 +41,9 @@ type userInputs struct {
 
 type modelIter struct {
 	inputs     []textinput.Model
+	touched    []bool // Поля, ошибки которых уже показываются: измененные или после попытки отправки
 	focusIndex int
+	submitted  bool
 	userInputs userInputs
 	config     *config.Config
 }
@@ -48,6 +58,9 @@ func RunInteractive(cfg *config.Config) (userInputs, error) {
 	}
 
 	finalModel := finishedModel.(modelIter)
+	if !finalModel.submitted {
+		return userInputs{}, ErrInteractiveAborted
+	}
 	return finalModel.userInputs, nil
 }
 
@@ -55,10 +68,12 @@ func initialModel(cfg *config.Config) modelIter {
 	inputs := make([]textinput.Model, 4)
 	labels := []string{"File Path:", "Reading Speed (wpm):", "Has Visuals (y/n):", "Workers:"}
 	defaults := []string{"", strconv.Itoa(cfg.DefaultReadingSpeed), "n", strconv.Itoa(cfg.DefaultWorkers)}
+	validators := []textinput.ValidateFunc{validateFilePath, validateSpeed, validateVisuals, validateWorkers}
 
 	for i := range inputs {
 		t := textinput.New()
 		t.Placeholder = labels[i]
+		t.Validate = validators[i]
 		t.SetValue(defaults[i])
 		t.CharLimit = 70
 		inputs[i] = t
@@ -67,9 +82,56 @@ func initialModel(cfg *config.Config) modelIter {
 	inputs[0].Focus()
 
 	return modelIter{
-		inputs: inputs,
-		config: cfg,
+		inputs:  inputs,
+		touched: make([]bool, len(inputs)),
+		config:  cfg,
+	}
+}
+
+func validateFilePath(value string) error {
+	path := strings.TrimSpace(value)
+	if path == "" {
+		return errors.New("file path cannot be empty")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.New("file not found")
+	}
+	if info.IsDir() {
+		return errors.New("path is a directory")
+	}
+	return nil
+}
+
+func validateSpeed(value string) error {
+	if speed, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || speed <= 0 {
+		return fmt.Errorf("%w: enter a whole number of words per minute greater than 0", estimator.ErrInvalidSpeed)
 	}
+	return nil
+}
+
+func validateVisuals(value string) error {
+	if _, ok := parseYesNo(value); !ok {
+		return errors.New("enter y or n")
+	}
+	return nil
+}
+
+func validateWorkers(value string) error {
+	if workers, err := strconv.Atoi(strings.TrimSpace(value)); err != nil || workers < 1 {
+		return fmt.Errorf("%w: enter a whole number of at least 1", estimator.ErrInvalidWorkers)
+	}
+	return nil
+}
+
+func parseYesNo(value string) (yes, ok bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "yes":
+		return true, true
+	case "n", "no":
+		return false, true
+	}
+	return false, false
 }
 
 func (m modelIter) Init() tea.Cmd {
@@ -88,19 +150,24 @@ func (m modelIter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// Проверяем завершение ввода
 			if s == "enter" && m.focusIndex == len(m.inputs) {
-				// Проверяем, что путь к файлу не пустой
-				if strings.TrimSpace(m.inputs[0].Value()) == "" {
-					m.inputs[0].SetValue("Error: file path cannot be empty")
-					m.inputs[0].Focus()
-					m.focusIndex = 0
-					return m, nil
+				// Показываем ошибки всех полей и переходим к первому неверному
+				for i := range m.inputs {
+					m.touched[i] = true
+				}
+				for i := range m.inputs {
+					if m.inputs[i].Err != nil {
+						m.focusIndex = i
+						m.inputs[i].Focus()
+						return m, nil
+					}
 				}
 
-				// Если все поля заполнены корректно, сохраняем данные и выходим
-				m.userInputs.FilePath = m.inputs[0].Value()
-				m.userInputs.ReadingSpeed, _ = strconv.Atoi(m.inputs[1].Value())
-				m.userInputs.HasVisuals = strings.ToLower(m.inputs[2].Value()) == "y"
-				m.userInputs.Workers, _ = strconv.Atoi(m.inputs[3].Value())
+				// Все поля заполнены корректно, сохраняем данные и выходим
+				m.userInputs.FilePath = strings.TrimSpace(m.inputs[0].Value())
+				m.userInputs.ReadingSpeed, _ = strconv.Atoi(strings.TrimSpace(m.inputs[1].Value()))
+				m.userInputs.HasVisuals, _ = parseYesNo(m.inputs[2].Value())
+				m.userInputs.Workers, _ = strconv.Atoi(strings.TrimSpace(m.inputs[3].Value()))
+				m.submitted = true
 
 				return m, tea.Quit
 			}
@@ -138,7 +205,12 @@ func (m modelIter) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m *modelIter) updateInputs(msg tea.Msg) tea.Cmd {
 	cmds := make([]tea.Cmd, len(m.inputs))
 	for i := range m.inputs {
+		before := m.inputs[i].Value()
 		m.inputs[i], cmds[i] = m.inputs[i].Update(msg)
+		// Ошибку поля показываем, как только его начали редактировать
+		if m.inputs[i].Value() != before {
+			m.touched[i] = true
+		}
 	}
 	return tea.Batch(cmds...)
 }
@@ -152,6 +224,10 @@ func (m modelIter) View() string {
 	for i := range m.inputs {
 		b.WriteString(inputStyle.Render(m.inputs[i].View()))
 		b.WriteString("\n")
+		if m.touched[i] && m.inputs[i].Err != nil {
+			b.WriteString(errorStyle.Render("✗ " + m.inputs[i].Err.Error()))
+			b.WriteString("\n")
+		}
 	}
 
 	button := blurredStyle.Render("[ Submit ]")
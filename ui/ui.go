@@ -2,7 +2,8 @@ package ui
 
 import (
 	"fmt"
-	_ "strings"
+	"os"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
@@ -56,6 +57,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.viewport.Width = msg.Width
 		m.viewport.Height = msg.Height - verticalMargin
+		// Контент зависит от ширины окна, поэтому пересобираем его здесь, а не в View
+		m.viewport.SetContent(m.content())
 		m.ready = true
 		return m, nil
 	}
@@ -65,23 +68,69 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
-func (m model) View() string {
-	if !m.ready {
-		return "Инициализация..."
-	}
-
-	// Собираем вывод на экран
+// content собирает текст результатов для viewport
+func (m model) content() string {
 	content := titleStyle.Render("LitTime Results") + "\n\n"
-	content += resultStyle.Render(fmt.Sprintf("Reading time: %s", highlightStyle.Render(fmt.Sprintf("%.2f min", m.result.ReadingTime)))) + "\n"
+	content += resultStyle.Render(fmt.Sprintf("Reading time: %s", highlightStyle.Render(fmt.Sprintf("%.2f min", m.result.ReadingTime))))
+	if m.result.Mode != "" {
+		content += infoStyle.Render(fmt.Sprintf(" (%s)", m.result.Mode))
+	}
+	content += "\n"
+	for _, mode := range estimator.ReadingModes() {
+		if minutes, ok := m.result.ModeTimes[mode]; ok {
+			content += infoStyle.Render(fmt.Sprintf("  %-7s %.2f min", mode, minutes)) + "\n"
+		}
+	}
 	content += resultStyle.Render(fmt.Sprintf("Words: %s", highlightStyle.Render(fmt.Sprintf("%d", m.result.WordCount)))) + "\n"
 	content += resultStyle.Render(fmt.Sprintf("Sentences: %s", highlightStyle.Render(fmt.Sprintf("%d", m.result.SentenceCount)))) + "\n"
 	content += resultStyle.Render(fmt.Sprintf("Syllables: %s", highlightStyle.Render(fmt.Sprintf("%d", m.result.SyllableCount)))) + "\n"
-	content += resultStyle.Render(fmt.Sprintf("Flesch-Kincaid Index: %s", highlightStyle.Render(fmt.Sprintf("%.2f", m.result.FleschKincaidIndex)))) + "\n"
+	fleschKincaid := fmt.Sprintf("%.2f", m.result.FleschKincaidIndex)
+	if m.result.FleschKincaidNotApplicable {
+		fleschKincaid = "n/a"
+	}
+	content += resultStyle.Render(fmt.Sprintf("Flesch-Kincaid Index: %s", highlightStyle.Render(fleschKincaid))) + "\n"
+	for _, c := range m.result.CEFR {
+		content += resultStyle.Render(fmt.Sprintf("CEFR level (%s): %s", c.Language, highlightStyle.Render(string(c.Level))))
+		content += infoStyle.Render(fmt.Sprintf(" · %.0f%% outside core vocabulary · %.1f words per sentence", c.OutsideCoreVocabulary, c.AverageSentenceWords)) + "\n"
+	}
 
-	// Обновляем контент viewport
-	m.viewport.SetContent(content)
+	// Список самых сложных предложений прокручивается вместе с остальным содержимым
+	if len(m.result.HardestSentences) > 0 {
+		content += "\n" + titleStyle.Render("Hardest sentences") + "\n\n"
+		textStyle := resultStyle.Width(max(m.viewport.Width-4, 20)).PaddingLeft(4)
+		for i, s := range m.result.HardestSentences {
+			content += highlightStyle.Render(fmt.Sprintf("%2d. ", i+1))
+			content += infoStyle.Render(fmt.Sprintf("paragraph %d · %d words · index %.2f", s.Paragraph, s.WordCount, s.FleschKincaidIndex)) + "\n"
+			content += textStyle.Render(s.Text) + "\n\n"
+		}
+	}
 
-	return fmt.Sprintf("%s\n%s", m.viewport.View(), infoStyle.Render("Press q to quit"))
+	if len(m.result.Vocabulary) > 0 {
+		content += "\n" + titleStyle.Render("Vocabulary") + "\n\n"
+		for _, v := range m.result.Vocabulary {
+			content += highlightStyle.Render(v.Language) + infoStyle.Render(fmt.Sprintf(" · %d words · %d distinct", v.Tokens, v.Types)) + "\n"
+			content += resultStyle.Render(fmt.Sprintf("  Type/token ratio: %.2f   MTLD: %.1f   Hapax: %d   Avg. length: %.1f",
+				v.TypeTokenRatio, v.MTLD, v.HapaxLegomena, v.AverageWordLength)) + "\n"
+			if v.FrequencyListSize > 0 {
+				content += resultStyle.Render(fmt.Sprintf("  Outside top %d: %.1f%%", v.FrequencyListSize, v.OutsideFrequencyList)) + "\n"
+			}
+			lemmas := make([]string, len(v.TopLemmas))
+			for i, l := range v.TopLemmas {
+				lemmas[i] = fmt.Sprintf("%s (%d)", l.Lemma, l.Count)
+			}
+			content += infoStyle.Width(max(m.viewport.Width-2, 20)).PaddingLeft(2).Render("Top: "+strings.Join(lemmas, ", ")) + "\n\n"
+		}
+	}
+
+	return content
+}
+
+func (m model) View() string {
+	if !m.ready {
+		return "Инициализация..."
+	}
+
+	return fmt.Sprintf("%s\n%s", m.viewport.View(), infoStyle.Render("↑/↓: scroll • q: quit"))
 }
 
 func RunUI(result *estimator.Result) error {
@@ -93,3 +142,16 @@ func RunUI(result *estimator.Result) error {
 	_, err := p.Run()
 	return err
 }
+
+// IsTerminal сообщает, подключены ли stdin и stdout к терминалу
+func IsTerminal() bool {
+	return isCharDevice(os.Stdin) && isCharDevice(os.Stdout)
+}
+
+func isCharDevice(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}